@@ -0,0 +1,38 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+const (
+	// MigrationAnnotation allows changing the fields that are otherwise immutable on a running control plane,
+	// such as the DataStore name or the ExternalClusterReference placement.
+	// The annotation must be set on the KamajiControlPlane for the whole duration of the change.
+	MigrationAnnotation = "kamaji.controlplane.cluster.x-k8s.io/allow-migration"
+	// UpgradeFailureAcknowledgedAnnotation acknowledges a failed Kubernetes version upgrade, allowing further ones:
+	// the annotation is removed by the controller once processed.
+	UpgradeFailureAcknowledgedAnnotation = "kamaji.controlplane.cluster.x-k8s.io/acknowledge-upgrade-failure"
+	// RolloutRestartedAtAnnotation is stamped on the Tenant Control Plane pods with the rolloutAfter value,
+	// forcing their restart once the requested time has passed.
+	RolloutRestartedAtAnnotation = "kamaji.controlplane.cluster.x-k8s.io/restartedAt"
+	// RotateCertificatesAnnotation requests Kamaji to regenerate the Tenant Control Plane certificates and kubeconfigs,
+	// except the Certificate Authorities: the annotation is removed by the controller once processed.
+	RotateCertificatesAnnotation = "kamaji.controlplane.cluster.x-k8s.io/rotate-certificates"
+	// InfrastructurePatchActionAnnotation is set by the infrastructure providers on the InfraCluster CustomResourceDefinition
+	// to declare how the control plane endpoint is reconciled on their InfraCluster: one of Patch, Check, or CheckOrPatch.
+	InfrastructurePatchActionAnnotation = "kamaji.controlplane.cluster.x-k8s.io/infrastructure-patch-action"
+	// InfrastructureHostFieldAnnotation is set on the InfraCluster CustomResourceDefinition with the dot separated
+	// field path of the control plane endpoint host, when not the Cluster API contract one.
+	InfrastructureHostFieldAnnotation = "kamaji.controlplane.cluster.x-k8s.io/infrastructure-host-field"
+	// InfrastructurePortFieldAnnotation is set on the InfraCluster CustomResourceDefinition with the dot separated
+	// field path of the control plane endpoint port, when not the Cluster API contract one.
+	InfrastructurePortFieldAnnotation = "kamaji.controlplane.cluster.x-k8s.io/infrastructure-port-field"
+	// InfrastructureReadyFieldAnnotation is set on the InfraCluster CustomResourceDefinition with the dot separated
+	// field path of the status readiness field, set to true upon patching, for the providers not managing it.
+	InfrastructureReadyFieldAnnotation = "kamaji.controlplane.cluster.x-k8s.io/infrastructure-ready-field"
+	// PropagatedAnnotationsAnnotation is stamped on the TenantControlPlane with the keys of the annotations
+	// propagated from the KamajiControlPlane, allowing to remove them once no longer declared.
+	PropagatedAnnotationsAnnotation = "kamaji.controlplane.cluster.x-k8s.io/propagated-annotations"
+	// LastAppliedTenantControlPlaneAnnotation is stamped on the TenantControlPlane with the fields last applied
+	// from the KamajiControlPlane, telling apart the KamajiControlPlane changes from the drifts.
+	LastAppliedTenantControlPlaneAnnotation = "kamaji.controlplane.cluster.x-k8s.io/last-applied-tenantcontrolplane"
+)
@@ -16,4 +16,18 @@ var (
 	KubeadmResourcesCreatedReadyConditionType   KamajiControlPlaneConditionType = "KubeadmResourcesCreated"
 	AvailableConditionType                      KamajiControlPlaneConditionType = "Available"
 	PausedConditionType                         KamajiControlPlaneConditionType = "Paused"
+	UpdateConstraintsSatisfiedConditionType     KamajiControlPlaneConditionType = "UpdateConstraintsSatisfied"
+	UpgradeInProgressConditionType              KamajiControlPlaneConditionType = "UpgradeInProgress"
+	RolloutInProgressConditionType              KamajiControlPlaneConditionType = "RolloutInProgress"
+	CertificatesValidConditionType              KamajiControlPlaneConditionType = "CertificatesValid"
+	KubeconfigsIssuedConditionType              KamajiControlPlaneConditionType = "KubeconfigsIssued"
+	AddonBundlesAppliedConditionType            KamajiControlPlaneConditionType = "AddonBundlesApplied"
+	ControlPlaneHealthyConditionType            KamajiControlPlaneConditionType = "ControlPlaneHealthy"
+	NodeVersionSkewSatisfiedConditionType       KamajiControlPlaneConditionType = "NodeVersionSkewSatisfied"
+	AutoscalingActiveConditionType              KamajiControlPlaneConditionType = "AutoscalingActive"
+	ResourcesRecommendedConditionType           KamajiControlPlaneConditionType = "ResourcesRecommended"
+	TenantControlPlaneInSyncConditionType       KamajiControlPlaneConditionType = "TenantControlPlaneInSync"
 )
+
+// AddonBundleAppliedConditionType reports whether the manifests of an addon bundle have been applied to the workload cluster.
+const AddonBundleAppliedConditionType = "Applied"
@@ -7,6 +7,7 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -162,8 +163,136 @@ type KamajiControlPlaneSpec struct {
 	// Defaults to 2.
 	// +kubebuilder:default=2
 	Replicas *int32 `json:"replicas,omitempty"`
+	// Autoscaling enables the horizontal autoscaling of the Tenant Control Plane replicas with a HorizontalPodAutoscaler
+	// managed by the controller, targeting the KamajiControlPlane scale subresource: the replicas field must not be
+	// managed by other controllers, such as the Cluster topology one. Not supported with ExternalClusterReference,
+	// since the Tenant Control Plane pods are running in a different cluster.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 	// Version defines the desired Kubernetes version.
 	Version string `json:"version"`
+	// UpgradePolicy enables the automatic rollback of Kubernetes version upgrades not completing in time:
+	// when unset, an upgrade is tracked with no deadline.
+	// +optional
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
+	// RolloutAfter is a field to indicate a rollout should be performed
+	// after the specified time even if no changes have been made to the
+	// KamajiControlPlane: the Tenant Control Plane pods are restarted once the time has passed.
+	// Setting it to the current time triggers an immediate rollout, as with clusterctl alpha rollout restart.
+	// +optional
+	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
+	// CertificatesExpiryWindow is the time before the expiration of a Tenant Control Plane certificate
+	// the CertificatesValid condition is reported as false. Defaults to 720h.
+	// +optional
+	CertificatesExpiryWindow *metav1.Duration `json:"certificatesExpiryWindow,omitempty"`
+	// Kubeconfigs are additional kubeconfigs issued for the workload cluster, in addition to the admin one:
+	// their client certificates are signed by the tenant Certificate Authority and renewed before expiring.
+	// Permissions must be granted to the given user or groups with RBAC in the workload cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Kubeconfigs []KubeconfigSpec `json:"kubeconfigs,omitempty"`
+	// NodeVersionSkewPolicy defines the kubelet versions skew supported by the control plane:
+	// when unset, kubelets up to three minor versions older than the control plane are supported,
+	// and the upgrades are not blocked.
+	// +optional
+	NodeVersionSkewPolicy *NodeVersionSkewPolicy `json:"nodeVersionSkewPolicy,omitempty"`
+}
+
+// AutoscalingSpec defines the bounds and the targets of the Tenant Control Plane horizontal autoscaling.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas cannot be greater than maxReplicas"
+// +kubebuilder:validation:XValidation:rule="has(self.targetCPUUtilizationPercentage) || has(self.targetRequestRate)",message="at least one of targetCPUUtilizationPercentage or targetRequestRate is required"
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit of the Tenant Control Plane replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit of the Tenant Control Plane replicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the average CPU utilization of the Tenant Control Plane pods,
+	// as a percentage of the requested CPU.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetRequestRate is the average API server request rate of the Tenant Control Plane pods.
+	// +optional
+	TargetRequestRate *RequestRateTarget `json:"targetRequestRate,omitempty"`
+}
+
+// RequestRateTarget defines the API server request rate target, as a pods metric served by the custom metrics API,
+// such as the one exposed by the Prometheus Adapter from the apiserver_request_total counter.
+type RequestRateTarget struct {
+	// MetricName is the name of the pods metric reporting the API server requests per second.
+	// +kubebuilder:default=apiserver_request_rate
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
+	// AverageValue is the target number of requests per second per pod.
+	AverageValue resource.Quantity `json:"averageValue"`
+}
+
+// NodeVersionSkewPolicy defines how the kubelet versions of the workload cluster Nodes are compared to the control plane one.
+type NodeVersionSkewPolicy struct {
+	// MaxMinorVersionSkew is the number of minor versions a kubelet is allowed to be older than the control plane.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3
+	// +optional
+	MaxMinorVersionSkew *int32 `json:"maxMinorVersionSkew,omitempty"`
+	// BlockUpgrades refuses the control plane upgrades leaving existing Nodes outside the supported skew:
+	// the TenantControlPlane is left untouched until the Nodes are upgraded, or the version is amended.
+	// +optional
+	BlockUpgrades bool `json:"blockUpgrades,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ControlPlaneEndpoint;Service
+
+// KubeconfigEndpoint selects the Kubernetes API Server address used by an issued kubeconfig.
+type KubeconfigEndpoint string
+
+const (
+	// KubeconfigEndpointControlPlane is the Control Plane endpoint advertised to the workload cluster.
+	KubeconfigEndpointControlPlane KubeconfigEndpoint = "ControlPlaneEndpoint"
+	// KubeconfigEndpointService is the Tenant Control Plane Service, reachable from the cluster it's deployed to.
+	KubeconfigEndpointService KubeconfigEndpoint = "Service"
+)
+
+// KubeconfigSpec defines a kubeconfig issued for the workload cluster,
+// stored in the <cluster>-kubeconfig-<name> Secret under the value key.
+type KubeconfigSpec struct {
+	// Name is used as suffix of the Secret name.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// User is the Common Name of the client certificate, used as user name by the Kubernetes API Server.
+	// +kubebuilder:validation:MinLength=1
+	User string `json:"user"`
+	// Groups are the Organizations of the client certificate, used as groups by the Kubernetes API Server.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// TTL is the validity of the client certificate, renewed once two thirds of it have elapsed.
+	// Must be at least 10m.
+	// +kubebuilder:default="24h"
+	// +optional
+	TTL metav1.Duration `json:"ttl,omitempty"`
+	// Endpoint selects the Kubernetes API Server address used by the kubeconfig.
+	// +kubebuilder:default=ControlPlaneEndpoint
+	// +optional
+	Endpoint KubeconfigEndpoint `json:"endpoint,omitempty"`
+	// Server rewrites the server URL of the kubeconfig, taking precedence over the endpoint,
+	// such as with the Ingress or Gateway hostname, the LoadBalancer IP, or an internal Service DNS name.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +optional
+	Server string `json:"server,omitempty"`
+}
+
+// UpgradePolicy defines how the Kubernetes version upgrades must be supervised.
+type UpgradePolicy struct {
+	// ProgressDeadline is the maximum duration for an upgrade to complete, starting from its detection.
+	// Once exceeded, the upgrade is marked as failed and the last known-good TenantControlPlane spec is restored:
+	// further upgrades are refused until the failure is acknowledged with the
+	// kamaji.controlplane.cluster.x-k8s.io/acknowledge-upgrade-failure annotation.
+	ProgressDeadline metav1.Duration `json:"progressDeadline"`
 }
 
 type DeploymentComponent struct {
@@ -231,6 +360,134 @@ type KamajiControlPlaneFields struct {
 	Network NetworkComponent `json:"network,omitempty"`
 	// Configure how the TenantControlPlane Deployment object should be configured.
 	Deployment DeploymentComponent `json:"deployment,omitempty"`
+	// Configure the admin kubeconfig replicated in the Cluster API <cluster>-kubeconfig Secret,
+	// used by the Cluster API controllers running in the management cluster.
+	// +optional
+	AdminKubeconfig *AdminKubeconfigComponent `json:"adminKubeconfig,omitempty"`
+	// AddonBundles are the manifests applied to the workload cluster with server-side apply
+	// once the control plane is initialized, such as the CNI, the cloud controller manager, or the CSI driver.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AddonBundles []AddonBundle `json:"addonBundles,omitempty"`
+	// ResourceRecommendations enables the right-sizing of the control plane components resource requests,
+	// computed from the usage of the TenantControlPlane pods reported by the metrics.k8s.io API.
+	// +optional
+	ResourceRecommendations *ResourceRecommendationsSpec `json:"resourceRecommendations,omitempty"`
+	// DriftPolicy defines how the changes applied directly to the TenantControlPlane are handled:
+	// in both cases, the drifted fields are reported with the TenantControlPlaneInSync condition.
+	// +kubebuilder:default=Enforce
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Enforce;Report
+
+// DriftPolicy defines whether the TenantControlPlane drifts from the KamajiControlPlane are reverted.
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce reverts the drifted fields to the values declared by the KamajiControlPlane.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+	// DriftPolicyReport leaves the drifted fields in place, the KamajiControlPlane changes being still applied.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// +kubebuilder:validation:Enum=Recommend;Auto
+
+// ResourceRecommendationsMode defines whether the resource recommendations are applied to the TenantControlPlane.
+type ResourceRecommendationsMode string
+
+const (
+	// ResourceRecommendationsModeRecommend publishes the recommendations in status only.
+	ResourceRecommendationsModeRecommend ResourceRecommendationsMode = "Recommend"
+	// ResourceRecommendationsModeAuto applies the recommendations to the TenantControlPlane during the maintenance window.
+	ResourceRecommendationsModeAuto ResourceRecommendationsMode = "Auto"
+)
+
+// ResourceRecommendationsSpec defines how the control plane components resource requests are recommended and applied.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'Auto' || has(self.maintenanceWindow)",message="maintenanceWindow is required with the Auto mode"
+type ResourceRecommendationsSpec struct {
+	// Mode defines whether the recommendations are applied to the TenantControlPlane.
+	// +kubebuilder:default=Recommend
+	// +optional
+	Mode ResourceRecommendationsMode `json:"mode,omitempty"`
+	// ObservationWindow is the period the peak usage is observed for, before computing the recommendations.
+	// +kubebuilder:default="24h"
+	// +optional
+	ObservationWindow metav1.Duration `json:"observationWindow,omitempty"`
+	// HeadroomPercentage is added to the observed peak usage when computing the recommended requests.
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HeadroomPercentage int32 `json:"headroomPercentage,omitempty"`
+	// MinAllowed is the lower bound of the recommended requests, for each component.
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed is the upper bound of the recommended requests, for each component.
+	// The recommended requests never exceed the limits declared for the component.
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+	// MaintenanceWindow is the daily window the recommendations are applied in, with the Auto mode:
+	// changing the requests rolls out the Tenant Control Plane pods.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow defines a daily time window, in UTC.
+type MaintenanceWindow struct {
+	// Start is the time of the day the window opens, in the HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// +kubebuilder:validation:Enum=ApplyOnce;Reconcile
+
+// AddonBundleMode defines how the manifests of an addon bundle are applied to the workload cluster.
+type AddonBundleMode string
+
+const (
+	// AddonBundleModeApplyOnce applies the manifests once, leaving the workload cluster resources to their owners.
+	AddonBundleModeApplyOnce AddonBundleMode = "ApplyOnce"
+	// AddonBundleModeReconcile applies the manifests again upon changes, and periodically to revert drifts.
+	AddonBundleModeReconcile AddonBundleMode = "Reconcile"
+)
+
+// AddonBundle references a ConfigMap or a Secret holding the manifests to apply to the workload cluster.
+type AddonBundle struct {
+	// Name identifies the bundle in the status.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Kind of the object holding the manifests, living in the KamajiControlPlane Namespace.
+	// Each key holds YAML or JSON manifests, multiple documents are supported: keys are applied in lexical order.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+	// ResourceName is the name of the ConfigMap or Secret holding the manifests.
+	// +kubebuilder:validation:MinLength=1
+	ResourceName string `json:"resourceName"`
+	// Mode defines how the manifests are applied.
+	// +kubebuilder:default=Reconcile
+	// +optional
+	Mode AddonBundleMode `json:"mode,omitempty"`
+}
+
+// AdminKubeconfigComponent defines the admin kubeconfig replicated for the Cluster API controllers.
+type AdminKubeconfigComponent struct {
+	// SecretKey is the key of the Kamaji admin kubeconfig Secret to replicate:
+	// admin.conf targets the Tenant Control Plane endpoint, admin.svc the Tenant Control Plane Service.
+	// When unset, admin.svc is used if the advertiseAddress is set, admin.conf otherwise,
+	// unless overridden by the kamaji.clastix.io/kubeconfig-secret-key annotation, which is deprecated.
+	// +kubebuilder:validation:Enum=admin.conf;admin.svc
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// Server rewrites the server URL of the replicated kubeconfig, such as with the Ingress or Gateway hostname,
+	// the LoadBalancer IP, or an internal Service DNS name.
+	// The address must be covered by the API Server certificate SANs, refer to the certSANs network field.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +optional
+	Server string `json:"server,omitempty"`
 }
 
 type ExternalClusterReference struct {
@@ -284,6 +541,228 @@ func (s *KamajiControlPlaneStatus) SetControlPlaneInitialized(value bool) {
 	s.Initialization.ControlPlaneInitialized = &value
 }
 
+// KamajiControlPlaneUpgradePhase describes the stage of a Kubernetes version upgrade.
+// +kubebuilder:validation:Enum=Pending;Rolling;Verifying;Done;Failed
+type KamajiControlPlaneUpgradePhase string
+
+const (
+	// UpgradePhasePending means the new version has been requested, although not yet processed by Kamaji.
+	UpgradePhasePending KamajiControlPlaneUpgradePhase = "Pending"
+	// UpgradePhaseRolling means Kamaji is rolling out the control plane components with the new version.
+	UpgradePhaseRolling KamajiControlPlaneUpgradePhase = "Rolling"
+	// UpgradePhaseVerifying means the new version has been applied, waiting for every replica to be updated and available.
+	UpgradePhaseVerifying KamajiControlPlaneUpgradePhase = "Verifying"
+	// UpgradePhaseDone means every replica is running the new version.
+	UpgradePhaseDone KamajiControlPlaneUpgradePhase = "Done"
+	// UpgradePhaseFailed means the upgrade exceeded the progress deadline and has been rolled back.
+	UpgradePhaseFailed KamajiControlPlaneUpgradePhase = "Failed"
+)
+
+// KamajiControlPlaneUpgradeStatus tracks the progress of a Kubernetes version upgrade.
+type KamajiControlPlaneUpgradeStatus struct {
+	// Phase is the current stage of the upgrade.
+	Phase KamajiControlPlaneUpgradePhase `json:"phase"`
+	// PreviousVersion is the Kubernetes version the control plane is upgraded from.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	// TargetVersion is the Kubernetes version the control plane is upgraded to.
+	TargetVersion string `json:"targetVersion"`
+	// StartTime is the time the upgrade has been detected.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time every replica has been reported as updated and available.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// RollbackTime is the time the last known-good TenantControlPlane spec has been restored.
+	// +optional
+	RollbackTime *metav1.Time `json:"rollbackTime,omitempty"`
+}
+
+// IsInProgress returns true when the upgrade has been started but not yet completed, nil-safe.
+func (u *KamajiControlPlaneUpgradeStatus) IsInProgress() bool {
+	return u != nil && u.Phase != UpgradePhaseDone && u.Phase != UpgradePhaseFailed
+}
+
+// IsFailed returns true when the upgrade exceeded the progress deadline, nil-safe.
+func (u *KamajiControlPlaneUpgradeStatus) IsFailed() bool {
+	return u != nil && u.Phase == UpgradePhaseFailed
+}
+
+// KamajiControlPlaneRolloutStatus tracks the progress of a rollout requested with the rolloutAfter field.
+type KamajiControlPlaneRolloutStatus struct {
+	// After is the rolloutAfter value the rollout has been triggered for.
+	After metav1.Time `json:"after"`
+	// StartTime is the time the Tenant Control Plane pods restart has been requested.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// UpdatedReplicas is the number of replicas restarted since the rollout has been requested.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// CompletionTime is the time every replica has been restarted and reported as available.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsInProgress returns true when the rollout has been started but not yet completed, nil-safe.
+func (r *KamajiControlPlaneRolloutStatus) IsInProgress() bool {
+	return r != nil && r.CompletionTime == nil
+}
+
+// KamajiControlPlaneSecretStatus reports the replication of a Cluster API Secret from the TenantControlPlane ones.
+type KamajiControlPlaneSecretStatus struct {
+	// Purpose is the Cluster API Secret purpose used as name suffix, such as kubeconfig, ca, sa, or proxy.
+	Purpose string `json:"purpose"`
+	// Name is the name of the Cluster API Secret.
+	Name string `json:"name"`
+	// Ready is true when the Secret has been replicated with all the expected data.
+	Ready bool `json:"ready"`
+	// Message reports why the Secret could not be replicated, such as missing data in the Kamaji one.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Checksum is the checksum of the Kamaji Secret data replicated upon the last sync.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+	// LastSyncTime is the time the Secret has been last synced with a changed Kamaji Secret,
+	// such as upon a certificate or kubeconfig rotation.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// KamajiControlPlaneCertificateStatus reports the expiration of a Tenant Control Plane certificate.
+type KamajiControlPlaneCertificateStatus struct {
+	// Name identifies the certificate, such as ca, apiServer, or adminKubeconfig.
+	Name string `json:"name"`
+	// SecretName is the name of the Kamaji Secret storing the certificate.
+	SecretName string `json:"secretName"`
+	// NotAfter is the expiration time of the certificate.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// KamajiControlPlaneHealthStatus reports the health of the Tenant Control Plane API server, as probed with
+// the replicated admin kubeconfig.
+type KamajiControlPlaneHealthStatus struct {
+	// LastTransitionTime is the last time the outcome of the probes changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Probes reports the outcome of each probed health endpoint, such as /readyz and /livez.
+	// +listType=map
+	// +listMapKey=endpoint
+	// +optional
+	Probes []KamajiControlPlaneHealthProbeStatus `json:"probes,omitempty"`
+}
+
+// KamajiControlPlaneHealthProbeStatus reports the outcome of a single health endpoint probe.
+type KamajiControlPlaneHealthProbeStatus struct {
+	// Endpoint is the probed API server path.
+	Endpoint string `json:"endpoint"`
+	// Healthy is true when the API server reported the endpoint as passed.
+	Healthy bool `json:"healthy"`
+	// Message reports why the probe failed, such as the API server being unreachable.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Checks reports the result of the single checks listed in the verbose output.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Checks []KamajiControlPlaneHealthCheckStatus `json:"checks,omitempty"`
+}
+
+// KamajiControlPlaneHealthCheckStatus reports the result of a single API server health check, such as etcd.
+type KamajiControlPlaneHealthCheckStatus struct {
+	// Name of the check, as reported by the API server.
+	Name string `json:"name"`
+	// Healthy is true when the check passed.
+	Healthy bool `json:"healthy"`
+}
+
+// KamajiControlPlaneAutoscalingStatus reports the decisions of the HorizontalPodAutoscaler managing the replicas.
+type KamajiControlPlaneAutoscalingStatus struct {
+	// HorizontalPodAutoscalerName is the name of the managed HorizontalPodAutoscaler.
+	HorizontalPodAutoscalerName string `json:"horizontalPodAutoscalerName"`
+	// CurrentReplicas is the number of replicas last observed by the autoscaler.
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+	// DesiredReplicas is the number of replicas last computed by the autoscaler.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// LastScaleTime is the last time the autoscaler changed the number of replicas.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// Conditions reports the autoscaler decisions, such as AbleToScale, ScalingActive, and ScalingLimited.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// KamajiControlPlaneResourceRecommendationsStatus reports the right-sizing of the control plane components.
+type KamajiControlPlaneResourceRecommendationsStatus struct {
+	// ObservationStartTime is the start of the current observation window.
+	ObservationStartTime metav1.Time `json:"observationStartTime"`
+	// LastAppliedTime is the time the recommendations have been last applied to the TenantControlPlane.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+	// Components reports the usage and the recommended requests per control plane component.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Components []KamajiControlPlaneComponentResourcesStatus `json:"components,omitempty"`
+}
+
+// KamajiControlPlaneComponentResourcesStatus reports the usage and the recommended requests of a control plane component.
+type KamajiControlPlaneComponentResourcesStatus struct {
+	// Name of the component, such as apiServer, controllerManager, scheduler, or kine.
+	Name string `json:"name"`
+	// PeakUsage is the highest usage of a single pod observed in the current observation window.
+	// +optional
+	PeakUsage corev1.ResourceList `json:"peakUsage,omitempty"`
+	// Recommended are the requests computed from the peak usage of the last completed observation window.
+	// +optional
+	Recommended corev1.ResourceList `json:"recommended,omitempty"`
+	// Applied are the requests applied to the TenantControlPlane with the Auto mode, overriding the declared ones.
+	// +optional
+	Applied corev1.ResourceList `json:"applied,omitempty"`
+}
+
+// KamajiControlPlaneNodeVersionsStatus summarises the kubelet versions of the workload cluster Nodes.
+type KamajiControlPlaneNodeVersionsStatus struct {
+	// LastProbeTime is the time the workload cluster Nodes have been last listed.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// Nodes is the number of Nodes in the workload cluster.
+	Nodes int32 `json:"nodes"`
+	// KubeletVersions reports the number of Nodes per kubelet version.
+	// +listType=map
+	// +listMapKey=version
+	// +optional
+	KubeletVersions []KamajiControlPlaneKubeletVersionStatus `json:"kubeletVersions,omitempty"`
+}
+
+// KamajiControlPlaneKubeletVersionStatus reports the Nodes running a kubelet version.
+type KamajiControlPlaneKubeletVersionStatus struct {
+	// Version of the kubelet.
+	Version string `json:"version"`
+	// Nodes is the number of Nodes running the kubelet version.
+	Nodes int32 `json:"nodes"`
+	// Supported is false when the kubelet version is outside the skew supported by the desired control plane version.
+	Supported bool `json:"supported"`
+}
+
+// KamajiControlPlaneAddonBundleStatus reports the delivery of an addon bundle to the workload cluster.
+type KamajiControlPlaneAddonBundleStatus struct {
+	// Name of the addon bundle.
+	Name string `json:"name"`
+	// Checksum is the checksum of the manifests last applied.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+	// LastAppliedTime is the time the manifests have been last applied.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+	// Conditions reports the delivery of the bundle, such as the Applied one.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // KamajiControlPlaneStatus defines the observed state of KamajiControlPlane.
 type KamajiControlPlaneStatus struct {
 	// Initialization contains the initialization status of the KamajiControlPlane.
@@ -315,8 +794,55 @@ type KamajiControlPlaneStatus struct {
 	// The error message, if available, for the failing reconciliation.
 	FailureMessage string `json:"failureMessage,omitempty"`
 	// String representing the minimum Kubernetes version for the control plane machines in the cluster.
-	Version    string             `json:"version"`
-	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	Version string `json:"version"`
+	// Upgrade tracks the progress of the latest Kubernetes version upgrade.
+	// +optional
+	Upgrade *KamajiControlPlaneUpgradeStatus `json:"upgrade,omitempty"`
+	// ObservedClusterGeneration is the latest generation of the owning Cluster propagated to the TenantControlPlane,
+	// such as the cluster network settings.
+	// +optional
+	ObservedClusterGeneration int64 `json:"observedClusterGeneration,omitempty"`
+	// ObservedTenantControlPlaneGeneration is the latest generation of the TenantControlPlane observed by the controller.
+	// +optional
+	ObservedTenantControlPlaneGeneration int64 `json:"observedTenantControlPlaneGeneration,omitempty"`
+	// TenantControlPlaneUpdateTime is the time the latest TenantControlPlane generation has been pushed:
+	// the TenantControlPlane status reported by Kamaji before it doesn't reflect the current spec.
+	// +optional
+	TenantControlPlaneUpdateTime *metav1.Time `json:"tenantControlPlaneUpdateTime,omitempty"`
+	// Rollout tracks the progress of the latest rollout requested with the rolloutAfter field.
+	// +optional
+	Rollout *KamajiControlPlaneRolloutStatus `json:"rollout,omitempty"`
+	// Secrets reports the replication of the Cluster API Secrets for the workload cluster.
+	// +listType=map
+	// +listMapKey=purpose
+	// +optional
+	Secrets []KamajiControlPlaneSecretStatus `json:"secrets,omitempty"`
+	// Certificates reports the expiration of the Tenant Control Plane certificates.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Certificates []KamajiControlPlaneCertificateStatus `json:"certificates,omitempty"`
+	// AddonBundles reports the delivery of the addon bundles to the workload cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AddonBundles []KamajiControlPlaneAddonBundleStatus `json:"addonBundles,omitempty"`
+	// Autoscaling reports the decisions of the HorizontalPodAutoscaler managing the Tenant Control Plane replicas.
+	// +optional
+	Autoscaling *KamajiControlPlaneAutoscalingStatus `json:"autoscaling,omitempty"`
+	// ResourceRecommendations reports the observed usage and the recommended requests of the control plane components.
+	// +optional
+	ResourceRecommendations *KamajiControlPlaneResourceRecommendationsStatus `json:"resourceRecommendations,omitempty"`
+	// NodeVersions summarises the kubelet versions of the workload cluster Nodes.
+	// +optional
+	NodeVersions *KamajiControlPlaneNodeVersionsStatus `json:"nodeVersions,omitempty"`
+	// FirstAvailableTime is the time the KamajiControlPlane has been reported as Available for the first time.
+	// +optional
+	FirstAvailableTime *metav1.Time `json:"firstAvailableTime,omitempty"`
+	// Health reports the outcome of the latest probes of the Tenant Control Plane API server.
+	// +optional
+	Health     *KamajiControlPlaneHealthStatus `json:"health,omitempty"`
+	Conditions []metav1.Condition              `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,66 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	ErrVersionDowngrade    = errors.New("downgrading the Kubernetes version is not supported")
+	ErrVersionSkew         = errors.New("upgrading the Kubernetes version is supported one minor version at a time")
+	ErrKubeletVersionNewer = errors.New("kubelet must not be newer than the control plane")
+	ErrKubeletVersionSkew  = errors.New("kubelet is older than the supported minor versions skew")
+)
+
+// ValidateVersionUpgrade ensures the transition between the two Kubernetes versions is supported by the control plane,
+// rejecting downgrades and upgrades skipping one or more minor versions.
+// Unparsable versions are not compared, since reported by the spec validation.
+func ValidateVersionUpgrade(from, to string) error {
+	prev, err := version.ParseSemantic(from)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	next, err := version.ParseSemantic(to)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	if next.LessThan(prev) {
+		return errors.Wrapf(ErrVersionDowngrade, "from %s to %s", from, to)
+	}
+
+	if next.Major() != prev.Major() || next.Minor() > prev.Minor()+1 {
+		return errors.Wrapf(ErrVersionSkew, "from %s to %s", from, to)
+	}
+
+	return nil
+}
+
+// ValidateKubeletVersionSkew ensures the kubelet version is supported by the control plane one,
+// rejecting kubelets newer than the control plane or older than the allowed minor versions skew.
+// Unparsable versions are not compared, since reported by the spec validation.
+func ValidateKubeletVersionSkew(controlPlane, kubelet string, maxMinorSkew int32) error {
+	cp, err := version.ParseGeneric(controlPlane)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	kv, err := version.ParseGeneric(kubelet)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	if kv.Major() > cp.Major() || kv.Major() == cp.Major() && kv.Minor() > cp.Minor() {
+		return errors.Wrapf(ErrKubeletVersionNewer, "kubelet %s, control plane %s", kubelet, controlPlane)
+	}
+	// An older major version is beyond any supported minor versions skew.
+	if kv.Major() < cp.Major() || cp.Minor()-kv.Minor() > uint(maxMinorSkew) { //nolint:gosec
+		return errors.Wrapf(ErrKubeletVersionSkew, "kubelet %s, control plane %s", kubelet, controlPlane)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive
+)
+
+func TestValidateVersionUpgrade(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		err  error
+	}{
+		{name: "same version", from: "v1.32.0", to: "v1.32.0"},
+		{name: "patch upgrade", from: "v1.32.0", to: "v1.32.3"},
+		{name: "minor upgrade", from: "v1.32.3", to: "v1.33.0"},
+		{name: "missing prefix", from: "1.32.3", to: "v1.33.0"},
+		{name: "patch downgrade", from: "v1.32.3", to: "v1.32.0", err: ErrVersionDowngrade},
+		{name: "minor downgrade", from: "v1.33.0", to: "v1.32.3", err: ErrVersionDowngrade},
+		{name: "minor skip", from: "v1.31.0", to: "v1.33.0", err: ErrVersionSkew},
+		{name: "major upgrade", from: "v1.33.0", to: "v2.0.0", err: ErrVersionSkew},
+		{name: "unparsable previous version", from: "latest", to: "v1.33.0"},
+		{name: "unparsable next version", from: "v1.33.0", to: "latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateVersionUpgrade(tt.from, tt.to)
+			if tt.err != nil {
+				g.Expect(err).To(MatchError(tt.err))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestValidateKubeletVersionSkew(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane string
+		kubelet      string
+		maxMinorSkew int32
+		err          error
+	}{
+		{name: "same version", controlPlane: "v1.33.0", kubelet: "v1.33.0", maxMinorSkew: 3},
+		{name: "newer patch", controlPlane: "v1.33.0", kubelet: "v1.33.2", maxMinorSkew: 3},
+		{name: "older within the skew", controlPlane: "v1.33.0", kubelet: "v1.30.9", maxMinorSkew: 3},
+		{name: "older beyond the skew", controlPlane: "v1.33.0", kubelet: "v1.29.0", maxMinorSkew: 3, err: ErrKubeletVersionSkew},
+		{name: "older beyond a narrower skew", controlPlane: "v1.33.0", kubelet: "v1.31.0", maxMinorSkew: 1, err: ErrKubeletVersionSkew},
+		{name: "newer minor", controlPlane: "v1.33.0", kubelet: "v1.34.0", maxMinorSkew: 3, err: ErrKubeletVersionNewer},
+		{name: "newer major", controlPlane: "v1.33.0", kubelet: "v2.0.0", maxMinorSkew: 3, err: ErrKubeletVersionNewer},
+		{name: "older major", controlPlane: "v2.0.0", kubelet: "v1.33.0", maxMinorSkew: 3, err: ErrKubeletVersionSkew},
+		{name: "unparsable kubelet version", controlPlane: "v1.33.0", kubelet: "unknown", maxMinorSkew: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateKubeletVersionSkew(tt.controlPlane, tt.kubelet, tt.maxMinorSkew)
+			if tt.err != nil {
+				g.Expect(err).To(MatchError(tt.err))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// minKubeconfigTTL is the minimum validity of the issued kubeconfigs client certificates,
+// preventing them from being signed again at every reconciliation.
+const minKubeconfigTTL = 10 * time.Minute
+
+//+kubebuilder:webhook:path=/mutate-controlplane-cluster-x-k8s-io-v1alpha2-kamajicontrolplane,mutating=true,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanes,verbs=create;update,versions=v1alpha2,name=default.kamajicontrolplane.controlplane.cluster.x-k8s.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-controlplane-cluster-x-k8s-io-v1alpha2-kamajicontrolplane,mutating=false,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanes,verbs=create;update,versions=v1alpha2,name=validation.kamajicontrolplane.controlplane.cluster.x-k8s.io,admissionReviewVersions=v1
+
+// KamajiControlPlaneWebhook implements the defaulting and validating admission webhooks for the KamajiControlPlane.
+type KamajiControlPlaneWebhook struct{}
+
+var (
+	_ admission.Defaulter[*KamajiControlPlane] = &KamajiControlPlaneWebhook{}
+	_ admission.Validator[*KamajiControlPlane] = &KamajiControlPlaneWebhook{}
+)
+
+func (w *KamajiControlPlaneWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck
+	return ctrl.NewWebhookManagedBy(mgr, &KamajiControlPlane{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+func (w *KamajiControlPlaneWebhook) Default(_ context.Context, kcp *KamajiControlPlane) error {
+	// Normalising the version with the "v" prefix expected by Kamaji.
+	if kcp.Spec.Version != "" && !strings.HasPrefix(kcp.Spec.Version, "v") {
+		kcp.Spec.Version = "v" + kcp.Spec.Version
+	}
+
+	return nil
+}
+
+func (w *KamajiControlPlaneWebhook) ValidateCreate(_ context.Context, kcp *KamajiControlPlane) (admission.Warnings, error) {
+	allErrs := validateKamajiControlPlaneSpec(kcp.Spec, field.NewPath("spec"))
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("KamajiControlPlane").GroupKind(), kcp.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+func (w *KamajiControlPlaneWebhook) ValidateUpdate(_ context.Context, oldKCP, newKCP *KamajiControlPlane) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateKamajiControlPlaneSpec(newKCP.Spec, specPath)
+	allErrs = append(allErrs, validateKamajiControlPlaneUpgradeUpdate(oldKCP, newKCP, specPath.Child("version"))...)
+	allErrs = append(allErrs, validateKamajiControlPlanePlacementUpdate(oldKCP, newKCP, specPath)...)
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("KamajiControlPlane").GroupKind(), newKCP.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+func (w *KamajiControlPlaneWebhook) ValidateDelete(context.Context, *KamajiControlPlane) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateKamajiControlPlaneSpec(spec KamajiControlPlaneSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), "a Kubernetes version is required"))
+	} else if _, err := version.ParseSemantic(spec.Version); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), spec.Version, "must be a valid semantic version, such as v1.33.0"))
+	}
+
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *spec.Replicas, "must be greater than or equal to 0"))
+	}
+
+	for i, kubeconfig := range spec.Kubeconfigs {
+		if kubeconfig.TTL.Duration < minKubeconfigTTL {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubeconfigs").Index(i).Child("ttl"), kubeconfig.TTL.Duration.String(), "must be at least "+minKubeconfigTTL.String()))
+		}
+	}
+
+	return append(allErrs, validateKamajiControlPlaneFields(spec.KamajiControlPlaneFields, fldPath)...)
+}
+
+func validateKamajiControlPlaneFields(fields KamajiControlPlaneFields, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	networkPath := fldPath.Child("network")
+
+	if fields.Network.Ingress != nil && fields.Network.Gateway != nil {
+		allErrs = append(allErrs, field.Forbidden(networkPath.Child("gateway"), "ingress and gateway are mutually exclusive"))
+	}
+
+	for i, certSAN := range fields.Network.CertSANs {
+		// nil err means the entry is in the form of <HOST>:<PORT> which is not accepted by Kamaji
+		if _, _, err := net.SplitHostPort(certSAN); err == nil {
+			allErrs = append(allErrs, field.Invalid(networkPath.Child("certSANs").Index(i), certSAN, "a certificate SAN must be made of host only with no port"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateKamajiControlPlaneVersionUpdate(oldVersion, newVersion string, fldPath *field.Path) field.ErrorList {
+	if err := ValidateVersionUpgrade(oldVersion, newVersion); err != nil {
+		return field.ErrorList{field.Forbidden(fldPath, err.Error())}
+	}
+
+	return nil
+}
+
+// validateKamajiControlPlaneUpgradeUpdate refuses further upgrades when the latest one failed, until acknowledged:
+// reverting to the version running in the control plane is always allowed.
+func validateKamajiControlPlaneUpgradeUpdate(oldKCP, newKCP *KamajiControlPlane, fldPath *field.Path) field.ErrorList {
+	if !oldKCP.Status.Upgrade.IsFailed() {
+		return validateKamajiControlPlaneVersionUpdate(oldKCP.Spec.Version, newKCP.Spec.Version, fldPath)
+	}
+
+	if newKCP.Spec.Version == oldKCP.Spec.Version || newKCP.Spec.Version == oldKCP.Status.Version {
+		return nil
+	}
+
+	if _, ok := newKCP.GetAnnotations()[UpgradeFailureAcknowledgedAnnotation]; !ok {
+		return field.ErrorList{field.Forbidden(fldPath, "the upgrade to "+oldKCP.Status.Upgrade.TargetVersion+" failed, set the "+UpgradeFailureAcknowledgedAnnotation+" annotation to allow further upgrades")}
+	}
+
+	return validateKamajiControlPlaneVersionUpdate(oldKCP.Status.Version, newKCP.Spec.Version, fldPath)
+}
+
+// validateKamajiControlPlanePlacementUpdate ensures the fields defining where the Tenant Control Plane
+// data and workloads are placed are not changed, unless the migration has been explicitly requested.
+func validateKamajiControlPlanePlacementUpdate(oldKCP, newKCP *KamajiControlPlane, fldPath *field.Path) field.ErrorList {
+	if _, ok := newKCP.GetAnnotations()[MigrationAnnotation]; ok {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	if oldKCP.Spec.DataStoreName != "" && oldKCP.Spec.DataStoreName != newKCP.Spec.DataStoreName {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dataStoreName"), "field is immutable, set the "+MigrationAnnotation+" annotation to migrate the DataStore"))
+	}
+
+	oldECR, newECR := oldKCP.Spec.Deployment.ExternalClusterReference, newKCP.Spec.Deployment.ExternalClusterReference
+	ecrPath := fldPath.Child("deployment", "externalClusterReference")
+
+	switch {
+	case (oldECR == nil) != (newECR == nil):
+		allErrs = append(allErrs, field.Forbidden(ecrPath, "field cannot be set or unset at runtime, set the "+MigrationAnnotation+" annotation to migrate the Tenant Control Plane"))
+	case oldECR != nil && oldECR.DeploymentNamespace != newECR.DeploymentNamespace:
+		allErrs = append(allErrs, field.Forbidden(ecrPath.Child("deploymentNamespace"), "field is immutable, set the "+MigrationAnnotation+" annotation to migrate the Tenant Control Plane"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newKamajiControlPlane(mutate func(kcp *KamajiControlPlane)) *KamajiControlPlane {
+	kcp := &KamajiControlPlane{}
+	kcp.Name, kcp.Namespace = "tenant", "default"
+	kcp.Spec.Version = "v1.33.0"
+	kcp.Spec.DataStoreName = "default"
+	kcp.Spec.Replicas = ptr.To(int32(2))
+
+	if mutate != nil {
+		mutate(kcp)
+	}
+
+	return kcp
+}
+
+func TestKamajiControlPlaneWebhookDefault(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := newKamajiControlPlane(func(kcp *KamajiControlPlane) {
+		kcp.Spec.Version = "1.33.0"
+	})
+
+	g.Expect((&KamajiControlPlaneWebhook{}).Default(context.Background(), kcp)).To(Succeed())
+	g.Expect(kcp.Spec.Version).To(Equal("v1.33.0"))
+}
+
+//nolint:funlen
+func TestKamajiControlPlaneWebhookValidateCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(kcp *KamajiControlPlane)
+		err    string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name: "missing version",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Version = ""
+			},
+			err: "spec.version: Required value",
+		},
+		{
+			name: "invalid version",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Version = "latest"
+			},
+			err: "spec.version: Invalid value",
+		},
+		{
+			name: "negative replicas",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Replicas = ptr.To(int32(-1))
+			},
+			err: "spec.replicas: Invalid value",
+		},
+		{
+			name: "ingress and gateway",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Network.Ingress = &IngressComponent{Hostname: "tenant.example.com"}
+				kcp.Spec.Network.Gateway = &GatewayComponent{Name: "gateway", Namespace: "gateway-system", Hostname: "tenant.example.com"}
+			},
+			err: "spec.network.gateway: Forbidden: ingress and gateway are mutually exclusive",
+		},
+		{
+			name: "ingress only",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Network.Ingress = &IngressComponent{Hostname: "tenant.example.com:443"}
+			},
+		},
+		{
+			name: "certificate SAN with a port",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Network.CertSANs = []string{"tenant.example.com", "tenant.example.com:6443"}
+			},
+			err: "spec.network.certSANs[1]: Invalid value",
+		},
+		{
+			name: "IPv6 certificate SAN",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Network.CertSANs = []string{"fd00::1"}
+			},
+		},
+		{
+			name: "kubeconfig TTL too short",
+			mutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Kubeconfigs = []KubeconfigSpec{{Name: "ci", TTL: metav1.Duration{Duration: time.Minute}}}
+			},
+			err: "spec.kubeconfigs[0].ttl: Invalid value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := (&KamajiControlPlaneWebhook{}).ValidateCreate(context.Background(), newKamajiControlPlane(tt.mutate))
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+//nolint:funlen,maintidx
+func TestKamajiControlPlaneWebhookValidateUpdate(t *testing.T) {
+	failedUpgrade := func(kcp *KamajiControlPlane) {
+		kcp.Spec.Version = "v1.34.0"
+		kcp.Status.Version = "v1.33.0"
+		kcp.Status.Upgrade = &KamajiControlPlaneUpgradeStatus{Phase: UpgradePhaseFailed, PreviousVersion: "v1.33.0", TargetVersion: "v1.34.0"}
+	}
+
+	tests := []struct {
+		name      string
+		oldMutate func(kcp *KamajiControlPlane)
+		newMutate func(kcp *KamajiControlPlane)
+		err       string
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name: "minor upgrade",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Version = "v1.34.0"
+			},
+		},
+		{
+			name: "downgrade",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Version = "v1.32.0"
+			},
+			err: "downgrading the Kubernetes version is not supported",
+		},
+		{
+			name: "minor skip",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Version = "v1.35.0"
+			},
+			err: "upgrading the Kubernetes version is supported one minor version at a time",
+		},
+		{
+			name:      "upgrade after a failed one",
+			oldMutate: failedUpgrade,
+			newMutate: func(kcp *KamajiControlPlane) {
+				failedUpgrade(kcp)
+				kcp.Spec.Version = "v1.34.1"
+			},
+			err: "set the " + UpgradeFailureAcknowledgedAnnotation + " annotation to allow further upgrades",
+		},
+		{
+			name:      "revert after a failed upgrade",
+			oldMutate: failedUpgrade,
+			newMutate: func(kcp *KamajiControlPlane) {
+				failedUpgrade(kcp)
+				kcp.Spec.Version = "v1.33.0"
+			},
+		},
+		{
+			name:      "acknowledged failed upgrade",
+			oldMutate: failedUpgrade,
+			newMutate: func(kcp *KamajiControlPlane) {
+				failedUpgrade(kcp)
+				kcp.Spec.Version = "v1.34.1"
+				kcp.Annotations = map[string]string{UpgradeFailureAcknowledgedAnnotation: ""}
+			},
+		},
+		{
+			name: "DataStore change",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.DataStoreName = "other"
+			},
+			err: "spec.dataStoreName: Forbidden",
+		},
+		{
+			name: "DataStore migration",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.DataStoreName = "other"
+				kcp.Annotations = map[string]string{MigrationAnnotation: ""}
+			},
+		},
+		{
+			name: "external cluster reference set",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Deployment.ExternalClusterReference = &ExternalClusterReference{KubeconfigSecretName: "remote", KubeconfigSecretKey: "value", DeploymentNamespace: "tenants"}
+			},
+			err: "spec.deployment.externalClusterReference: Forbidden",
+		},
+		{
+			name: "external cluster reference namespace change",
+			oldMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Deployment.ExternalClusterReference = &ExternalClusterReference{KubeconfigSecretName: "remote", KubeconfigSecretKey: "value", DeploymentNamespace: "tenants"}
+			},
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Deployment.ExternalClusterReference = &ExternalClusterReference{KubeconfigSecretName: "remote", KubeconfigSecretKey: "value", DeploymentNamespace: "other"}
+			},
+			err: "spec.deployment.externalClusterReference.deploymentNamespace: Forbidden",
+		},
+		{
+			name: "external cluster reference migration",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Deployment.ExternalClusterReference = &ExternalClusterReference{KubeconfigSecretName: "remote", KubeconfigSecretKey: "value", DeploymentNamespace: "tenants"}
+				kcp.Annotations = map[string]string{MigrationAnnotation: ""}
+			},
+		},
+		{
+			name: "invalid spec",
+			newMutate: func(kcp *KamajiControlPlane) {
+				kcp.Spec.Network.CertSANs = []string{"tenant.example.com:6443"}
+			},
+			err: "spec.network.certSANs[0]: Invalid value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := (&KamajiControlPlaneWebhook{}).ValidateUpdate(context.Background(), newKamajiControlPlane(tt.oldMutate), newKamajiControlPlane(tt.newMutate))
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestKamajiControlPlaneTemplateWebhookValidate(t *testing.T) {
+	g := NewWithT(t)
+
+	kcpt := &KamajiControlPlaneTemplate{}
+	kcpt.Name = "tenant"
+	kcpt.Spec.Template.Spec.Network.CertSANs = []string{"tenant.example.com:6443"}
+
+	_, err := (&KamajiControlPlaneTemplateWebhook{}).ValidateCreate(context.Background(), kcpt)
+	g.Expect(err).To(MatchError(ContainSubstring("spec.template.spec.network.certSANs[0]: Invalid value")))
+
+	kcpt.Spec.Template.Spec.Network.CertSANs = []string{"tenant.example.com"}
+
+	_, err = (&KamajiControlPlaneTemplateWebhook{}).ValidateUpdate(context.Background(), kcpt, kcpt)
+	g.Expect(err).NotTo(HaveOccurred())
+}
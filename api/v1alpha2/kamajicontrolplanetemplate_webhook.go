@@ -0,0 +1,49 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-controlplane-cluster-x-k8s-io-v1alpha2-kamajicontrolplanetemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanetemplates,verbs=create;update,versions=v1alpha2,name=validation.kamajicontrolplanetemplate.controlplane.cluster.x-k8s.io,admissionReviewVersions=v1
+
+// KamajiControlPlaneTemplateWebhook implements the validating admission webhook for the KamajiControlPlaneTemplate.
+type KamajiControlPlaneTemplateWebhook struct{}
+
+var _ admission.Validator[*KamajiControlPlaneTemplate] = &KamajiControlPlaneTemplateWebhook{}
+
+func (w *KamajiControlPlaneTemplateWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck
+	return ctrl.NewWebhookManagedBy(mgr, &KamajiControlPlaneTemplate{}).
+		WithValidator(w).
+		Complete()
+}
+
+func (w *KamajiControlPlaneTemplateWebhook) ValidateCreate(_ context.Context, kcpt *KamajiControlPlaneTemplate) (admission.Warnings, error) {
+	return nil, w.validate(kcpt)
+}
+
+func (w *KamajiControlPlaneTemplateWebhook) ValidateUpdate(_ context.Context, _, newKCPT *KamajiControlPlaneTemplate) (admission.Warnings, error) {
+	return nil, w.validate(newKCPT)
+}
+
+func (w *KamajiControlPlaneTemplateWebhook) ValidateDelete(context.Context, *KamajiControlPlaneTemplate) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *KamajiControlPlaneTemplateWebhook) validate(kcpt *KamajiControlPlaneTemplate) error {
+	allErrs := validateKamajiControlPlaneFields(kcpt.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("KamajiControlPlaneTemplate").GroupKind(), kcpt.Name, allErrs)
+	}
+
+	return nil
+}
@@ -14,6 +14,36 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminKubeconfigComponent) DeepCopyInto(out *AdminKubeconfigComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminKubeconfigComponent.
+func (in *AdminKubeconfigComponent) DeepCopy() *AdminKubeconfigComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminKubeconfigComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonBundle) DeepCopyInto(out *AddonBundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonBundle.
+func (in *AddonBundle) DeepCopy() *AddonBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
 	*out = *in
@@ -35,6 +65,36 @@ func (in *AddonsSpec) DeepCopy() *AddonsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetRequestRate != nil {
+		in, out := &in.TargetRequestRate, &out.TargetRequestRate
+		*out = new(RequestRateTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneComponent) DeepCopyInto(out *ControlPlaneComponent) {
 	*out = *in
@@ -273,6 +333,110 @@ func (in *KamajiControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneAddonBundleStatus) DeepCopyInto(out *KamajiControlPlaneAddonBundleStatus) {
+	*out = *in
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneAddonBundleStatus.
+func (in *KamajiControlPlaneAddonBundleStatus) DeepCopy() *KamajiControlPlaneAddonBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneAddonBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneAutoscalingStatus) DeepCopyInto(out *KamajiControlPlaneAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneAutoscalingStatus.
+func (in *KamajiControlPlaneAutoscalingStatus) DeepCopy() *KamajiControlPlaneAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneCertificateStatus) DeepCopyInto(out *KamajiControlPlaneCertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneCertificateStatus.
+func (in *KamajiControlPlaneCertificateStatus) DeepCopy() *KamajiControlPlaneCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneComponentResourcesStatus) DeepCopyInto(out *KamajiControlPlaneComponentResourcesStatus) {
+	*out = *in
+	if in.PeakUsage != nil {
+		in, out := &in.PeakUsage, &out.PeakUsage
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Recommended != nil {
+		in, out := &in.Recommended, &out.Recommended
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneComponentResourcesStatus.
+func (in *KamajiControlPlaneComponentResourcesStatus) DeepCopy() *KamajiControlPlaneComponentResourcesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneComponentResourcesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KamajiControlPlaneFields) DeepCopyInto(out *KamajiControlPlaneFields) {
 	*out = *in
@@ -294,6 +458,21 @@ func (in *KamajiControlPlaneFields) DeepCopyInto(out *KamajiControlPlaneFields)
 	in.Kubelet.DeepCopyInto(&out.Kubelet)
 	in.Network.DeepCopyInto(&out.Network)
 	in.Deployment.DeepCopyInto(&out.Deployment)
+	if in.AdminKubeconfig != nil {
+		in, out := &in.AdminKubeconfig, &out.AdminKubeconfig
+		*out = new(AdminKubeconfigComponent)
+		**out = **in
+	}
+	if in.AddonBundles != nil {
+		in, out := &in.AddonBundles, &out.AddonBundles
+		*out = make([]AddonBundle, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(ResourceRecommendationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneFields.
@@ -306,6 +485,64 @@ func (in *KamajiControlPlaneFields) DeepCopy() *KamajiControlPlaneFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneHealthCheckStatus) DeepCopyInto(out *KamajiControlPlaneHealthCheckStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneHealthCheckStatus.
+func (in *KamajiControlPlaneHealthCheckStatus) DeepCopy() *KamajiControlPlaneHealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneHealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneHealthProbeStatus) DeepCopyInto(out *KamajiControlPlaneHealthProbeStatus) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]KamajiControlPlaneHealthCheckStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneHealthProbeStatus.
+func (in *KamajiControlPlaneHealthProbeStatus) DeepCopy() *KamajiControlPlaneHealthProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneHealthProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneHealthStatus) DeepCopyInto(out *KamajiControlPlaneHealthStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]KamajiControlPlaneHealthProbeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneHealthStatus.
+func (in *KamajiControlPlaneHealthStatus) DeepCopy() *KamajiControlPlaneHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KamajiControlPlaneInitializationStatus) DeepCopyInto(out *KamajiControlPlaneInitializationStatus) {
 	*out = *in
@@ -326,6 +563,21 @@ func (in *KamajiControlPlaneInitializationStatus) DeepCopy() *KamajiControlPlane
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneKubeletVersionStatus) DeepCopyInto(out *KamajiControlPlaneKubeletVersionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneKubeletVersionStatus.
+func (in *KamajiControlPlaneKubeletVersionStatus) DeepCopy() *KamajiControlPlaneKubeletVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneKubeletVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KamajiControlPlaneList) DeepCopyInto(out *KamajiControlPlaneList) {
 	*out = *in
@@ -358,6 +610,97 @@ func (in *KamajiControlPlaneList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneNodeVersionsStatus) DeepCopyInto(out *KamajiControlPlaneNodeVersionsStatus) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	if in.KubeletVersions != nil {
+		in, out := &in.KubeletVersions, &out.KubeletVersions
+		*out = make([]KamajiControlPlaneKubeletVersionStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneNodeVersionsStatus.
+func (in *KamajiControlPlaneNodeVersionsStatus) DeepCopy() *KamajiControlPlaneNodeVersionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneNodeVersionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneResourceRecommendationsStatus) DeepCopyInto(out *KamajiControlPlaneResourceRecommendationsStatus) {
+	*out = *in
+	in.ObservationStartTime.DeepCopyInto(&out.ObservationStartTime)
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]KamajiControlPlaneComponentResourcesStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneResourceRecommendationsStatus.
+func (in *KamajiControlPlaneResourceRecommendationsStatus) DeepCopy() *KamajiControlPlaneResourceRecommendationsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneResourceRecommendationsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneRolloutStatus) DeepCopyInto(out *KamajiControlPlaneRolloutStatus) {
+	*out = *in
+	in.After.DeepCopyInto(&out.After)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneRolloutStatus.
+func (in *KamajiControlPlaneRolloutStatus) DeepCopy() *KamajiControlPlaneRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneSecretStatus) DeepCopyInto(out *KamajiControlPlaneSecretStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneSecretStatus.
+func (in *KamajiControlPlaneSecretStatus) DeepCopy() *KamajiControlPlaneSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KamajiControlPlaneSpec) DeepCopyInto(out *KamajiControlPlaneSpec) {
 	*out = *in
@@ -368,6 +711,37 @@ func (in *KamajiControlPlaneSpec) DeepCopyInto(out *KamajiControlPlaneSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicy)
+		**out = **in
+	}
+	if in.RolloutAfter != nil {
+		in, out := &in.RolloutAfter, &out.RolloutAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.CertificatesExpiryWindow != nil {
+		in, out := &in.CertificatesExpiryWindow, &out.CertificatesExpiryWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Kubeconfigs != nil {
+		in, out := &in.Kubeconfigs, &out.Kubeconfigs
+		*out = make([]KubeconfigSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeVersionSkewPolicy != nil {
+		in, out := &in.NodeVersionSkewPolicy, &out.NodeVersionSkewPolicy
+		*out = new(NodeVersionSkewPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneSpec.
@@ -418,6 +792,65 @@ func (in *KamajiControlPlaneStatus) DeepCopyInto(out *KamajiControlPlaneStatus)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(KamajiControlPlaneUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TenantControlPlaneUpdateTime != nil {
+		in, out := &in.TenantControlPlaneUpdateTime, &out.TenantControlPlaneUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(KamajiControlPlaneRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]KamajiControlPlaneSecretStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]KamajiControlPlaneCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AddonBundles != nil {
+		in, out := &in.AddonBundles, &out.AddonBundles
+		*out = make([]KamajiControlPlaneAddonBundleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(KamajiControlPlaneAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(KamajiControlPlaneResourceRecommendationsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeVersions != nil {
+		in, out := &in.NodeVersions, &out.NodeVersions
+		*out = new(KamajiControlPlaneNodeVersionsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FirstAvailableTime != nil {
+		in, out := &in.FirstAvailableTime, &out.FirstAvailableTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(KamajiControlPlaneHealthStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -528,6 +961,33 @@ func (in *KamajiControlPlaneTemplateSpec) DeepCopy() *KamajiControlPlaneTemplate
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiControlPlaneUpgradeStatus) DeepCopyInto(out *KamajiControlPlaneUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RollbackTime != nil {
+		in, out := &in.RollbackTime, &out.RollbackTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiControlPlaneUpgradeStatus.
+func (in *KamajiControlPlaneUpgradeStatus) DeepCopy() *KamajiControlPlaneUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiControlPlaneUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KineComponent) DeepCopyInto(out *KineComponent) {
 	*out = *in
@@ -549,6 +1009,27 @@ func (in *KineComponent) DeepCopy() *KineComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSpec) DeepCopyInto(out *KubeconfigSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TTL = in.TTL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSpec.
+func (in *KubeconfigSpec) DeepCopy() *KubeconfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerConfig) DeepCopyInto(out *LoadBalancerConfig) {
 	*out = *in
@@ -574,6 +1055,22 @@ func (in *LoadBalancerConfig) DeepCopy() *LoadBalancerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkComponent) DeepCopyInto(out *NetworkComponent) {
 	*out = *in
@@ -634,3 +1131,90 @@ func (in *NetworkComponent) DeepCopy() *NetworkComponent {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeVersionSkewPolicy) DeepCopyInto(out *NodeVersionSkewPolicy) {
+	*out = *in
+	if in.MaxMinorVersionSkew != nil {
+		in, out := &in.MaxMinorVersionSkew, &out.MaxMinorVersionSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeVersionSkewPolicy.
+func (in *NodeVersionSkewPolicy) DeepCopy() *NodeVersionSkewPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeVersionSkewPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestRateTarget) DeepCopyInto(out *RequestRateTarget) {
+	*out = *in
+	out.AverageValue = in.AverageValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestRateTarget.
+func (in *RequestRateTarget) DeepCopy() *RequestRateTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestRateTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationsSpec) DeepCopyInto(out *ResourceRecommendationsSpec) {
+	*out = *in
+	out.ObservationWindow = in.ObservationWindow
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationsSpec.
+func (in *ResourceRecommendationsSpec) DeepCopy() *ResourceRecommendationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicy) DeepCopyInto(out *UpgradePolicy) {
+	*out = *in
+	out.ProgressDeadline = in.ProgressDeadline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy.
+func (in *UpgradePolicy) DeepCopy() *UpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
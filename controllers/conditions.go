@@ -4,11 +4,14 @@
 package controllers
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/metrics"
 )
 
 func TrackConditionType(conditions *[]metav1.Condition, conditionType v1alpha2.KamajiControlPlaneConditionType, observedGeneration int64, fn func() error) { //nolint:varnamelen
@@ -23,7 +26,17 @@ func TrackConditionType(conditions *[]metav1.Condition, conditionType v1alpha2.K
 		meta.SetStatusCondition(conditions, *condition)
 	}()
 
-	if err := fn(); err != nil {
+	start := time.Now()
+	err := fn()
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+
+	metrics.ReconcilePhaseDuration.WithLabelValues(string(conditionType), result).Observe(time.Since(start).Seconds())
+
+	if err != nil {
 		if condition.Status != metav1.ConditionFalse {
 			condition.LastTransitionTime = metav1.Now()
 		}
@@ -12,4 +12,6 @@ var (
 	ErrUnprocessedControlPlaneEndpoint = errors.New("Control Plane Endpoint is not yet available since unprocessed by Kamaji") //nolint:staticcheck
 	ErrUpdate                          = errors.New("cannot update KamajiControlPlane resource")
 	ErrClientSetCreation               = errors.New("cannot create Kubernetes Client-set")
+	ErrMetricsRegistration             = errors.New("cannot register metrics collectors")
+	ErrMissingSecretData               = errors.New("missing data in the Kamaji Secret")
 )
@@ -0,0 +1,82 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+// Reasons of the Events emitted by the controllers: these are stable and can be used for alerting.
+// Condition transitions are recorded with the condition type as reason.
+const (
+	EventReasonInfrastructureClusterPatchFailed       = "InfrastructureClusterPatchFailed"
+	EventReasonRemoteTenantControlPlaneDeleted        = "RemoteTenantControlPlaneDeleted"
+	EventReasonRemoteTenantControlPlaneDeletionFailed = "RemoteTenantControlPlaneDeletionFailed"
+	EventReasonUpgradeRolledBack                      = "UpgradeRolledBack"
+	EventReasonUpgradeRollbackFailed                  = "UpgradeRollbackFailed"
+	EventReasonUpgradeFailureAcknowledged             = "UpgradeFailureAcknowledged"
+	EventReasonRemoteManagerStarted                   = "RemoteManagerStarted"
+	EventReasonRemoteManagerStopped                   = "RemoteManagerStopped"
+	EventReasonRemoteManagerFailed                    = "RemoteManagerFailed"
+	EventReasonSecretSynced                           = "SecretSynced"
+	EventReasonCertificatesRotationRequested          = "CertificatesRotationRequested"
+	EventReasonAddonBundleApplied                     = "AddonBundleApplied"
+	EventReasonScaled                                 = "Scaled"
+	EventReasonResourcesRightSized                    = "ResourcesRightSized"
+	EventReasonDriftReverted                          = "DriftReverted"
+)
+
+// Actions of the Events emitted by the controllers.
+const (
+	EventActionReconcile = "Reconcile"
+	EventActionPatch     = "Patch"
+	EventActionDelete    = "Delete"
+	EventActionRollback  = "Rollback"
+	EventActionStart     = "Start"
+	EventActionStop      = "Stop"
+	EventActionSync      = "Sync"
+	EventActionRotate    = "Rotate"
+	EventActionApply     = "Apply"
+	EventActionScale     = "Scale"
+)
+
+// informationalConditionTypes are the conditions reporting an activity, rather than a healthy state:
+// their transitions are always recorded as Normal events, unless the related operation failed.
+var informationalConditionTypes = sets.New[v1alpha2.KamajiControlPlaneConditionType](
+	v1alpha2.PausedConditionType,
+	v1alpha2.UpgradeInProgressConditionType,
+	v1alpha2.RolloutInProgressConditionType,
+)
+
+// recordConditionEvents emits an Event for each condition whose status changed between the two sets.
+func recordConditionEvents(recorder events.EventRecorder, object runtime.Object, previous, current []metav1.Condition) {
+	for _, condition := range current {
+		if old := meta.FindStatusCondition(previous, condition.Type); old != nil && old.Status == condition.Status {
+			continue
+		}
+
+		eventType := corev1.EventTypeNormal
+		if condition.Status == metav1.ConditionFalse && !informationalConditionTypes.Has(v1alpha2.KamajiControlPlaneConditionType(condition.Type)) {
+			eventType = corev1.EventTypeWarning
+		}
+
+		if condition.Reason == "UpgradeFailed" {
+			eventType = corev1.EventTypeWarning
+		}
+
+		note := "condition " + condition.Type + " changed to " + string(condition.Status) + " (" + condition.Reason + ")"
+		if condition.Message != "" {
+			note += ": " + condition.Message
+		}
+
+		recorder.Eventf(object, nil, eventType, condition.Type, EventActionReconcile, "%s", note)
+	}
+}
@@ -11,8 +11,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -29,8 +31,13 @@ import (
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/indexers"
 )
 
+// kamajiSecretsSelector matches the Secrets generated by Kamaji for the TenantControlPlane instances,
+// such as the kubeconfig and the certificates ones: these are the only Secrets read from the external cluster.
+var kamajiSecretsSelector = labels.SelectorFromSet(labels.Set{"kamaji.clastix.io/project": "kamaji"})
+
 type ExternalClusterReferenceReconciler struct {
 	Client         client.Client
+	Recorder       events.EventRecorder
 	Store          externalclusterreference.Store
 	TriggerChannel chan event.GenericEvent
 }
@@ -64,6 +71,8 @@ func (r *ExternalClusterReferenceReconciler) Reconcile(ctx context.Context, req
 		if len(kcpList.Items) == 0 {
 			if r.Store.Stop(key) {
 				log.Info("stopping manager, unused")
+
+				r.Recorder.Eventf(&secret, nil, corev1.EventTypeNormal, EventReasonRemoteManagerStopped, EventActionStop, "manager for %s stopped, no longer referenced", key)
 			}
 
 			continue
@@ -89,6 +98,8 @@ func (r *ExternalClusterReferenceReconciler) Reconcile(ctx context.Context, req
 		if cfgErr != nil {
 			log.Error(cfgErr, "cannot generate REST config from Secret content", "key", key)
 
+			r.Recorder.Eventf(&secret, nil, corev1.EventTypeWarning, EventReasonRemoteManagerFailed, EventActionStart, "cannot generate REST config for %s: %s", key, cfgErr.Error())
+
 			return ctrl.Result{}, cfgErr //nolint:wrapcheck
 		}
 
@@ -99,12 +110,15 @@ func (r *ExternalClusterReferenceReconciler) Reconcile(ctx context.Context, req
 				ByObject: map[client.Object]cache.ByObject{
 					// Reduce memory overhead by only caching watched resources.
 					&kamajiv1alpha1.TenantControlPlane{}: {},
+					&corev1.Secret{}:                     {Label: kamajiSecretsSelector},
 				},
 			},
 		})
 		if err != nil {
 			log.Error(err, "cannot generate manager")
 
+			r.Recorder.Eventf(&secret, nil, corev1.EventTypeWarning, EventReasonRemoteManagerFailed, EventActionStart, "cannot generate manager for %s: %s", key, err.Error())
+
 			return ctrl.Result{}, err //nolint:wrapcheck
 		}
 
@@ -115,18 +129,22 @@ func (r *ExternalClusterReferenceReconciler) Reconcile(ctx context.Context, req
 		}
 
 		mgrCtx, cancelFn := context.WithCancel(ctx)
-		go r.startManager(mgrCtx, mgr, key)
+		go r.startManager(mgrCtx, mgr, &secret, key)
 
 		r.Store.Add(key, secret.ResourceVersion, mgr, cancelFn)
+
+		r.Recorder.Eventf(&secret, nil, corev1.EventTypeNormal, EventReasonRemoteManagerStarted, EventActionStart, "manager for %s started", key)
 	}
 
 	return ctrl.Result{}, nil
 }
 
-func (r *ExternalClusterReferenceReconciler) startManager(ctx context.Context, mgr ctrl.Manager, name string) {
+func (r *ExternalClusterReferenceReconciler) startManager(ctx context.Context, mgr ctrl.Manager, secret *corev1.Secret, name string) {
 	if mgrErr := mgr.Start(ctx); mgrErr != nil {
 		ctrllog.FromContext(ctx).Error(mgrErr, "manager cannot be started, external cluster reference could not work")
 
+		r.Recorder.Eventf(secret, nil, corev1.EventTypeWarning, EventReasonRemoteManagerFailed, EventActionStart, "manager for %s cannot be started: %s", name, mgrErr.Error())
+
 		r.Store.Stop(name)
 	}
 }
@@ -218,5 +236,8 @@ func (p *PushKamajiChange) SetupWithManager(mgr ctrl.Manager) error {
 			SkipNameValidation: ptr.To(true),
 		}).
 		For(&kamajiv1alpha1.TenantControlPlane{}).
+		// Kamaji rotates the kubeconfig and certificates Secrets without changing the TenantControlPlane:
+		// these must be replicated again as Cluster API Secrets in the management cluster.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(tenantControlPlaneSecretToOwner)).
 		Complete(p)
 }
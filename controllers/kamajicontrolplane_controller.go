@@ -7,19 +7,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/component-base/featuregate"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,6 +43,9 @@ import (
 	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/externalclusterreference"
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/features"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/indexers"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/metrics"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/tracing"
 )
 
 // KamajiControlPlaneReconciler reconciles a KamajiControlPlane object.
@@ -43,17 +54,47 @@ type KamajiControlPlaneReconciler struct {
 	FeatureGates                  featuregate.FeatureGate
 	MaxConcurrentReconciles       int
 	DynamicInfrastructureClusters sets.Set[string]
-
-	client     client.Client
-	restMapper meta.RESTMapper
+	// InfrastructurePatchStrategies references the ConfigMap declaring the InfraCluster patch strategies,
+	// in addition to the built-in ones.
+	InfrastructurePatchStrategies types.NamespacedName
+	// ClusterCache shares a single connection per workload cluster, such as for listing the Nodes,
+	// probing the API server health, and applying the addon bundles.
+	ClusterCache clustercache.ClusterCache
+	// RequeueBaseDelay and RequeueMaxDelay define the per-object exponential backoff
+	// used when waiting for a condition not yet satisfied, such as the infrastructure provisioning.
+	RequeueBaseDelay time.Duration
+	RequeueMaxDelay  time.Duration
+
+	client              client.Client
+	apiReader           client.Reader
+	restMapper          meta.RESTMapper
+	recorder            events.EventRecorder
+	waitBackoff         *waitBackoff
+	healthProbeSchedule *healthProbeSchedule
 }
 
 //+kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanes/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartRoot(ctx, "KamajiControlPlane.Reconcile",
+		attribute.String("k8s.namespace", req.Namespace),
+		attribute.String("k8s.name", req.Name),
+	)
+
+	r.waitBackoff.start(req)
+	result, err := r.reconcile(ctx, req)
+	r.waitBackoff.finish(req)
+	tracing.End(span, err)
+
+	return result, err
+}
 
-func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) { //nolint:funlen,cyclop,maintidx,gocognit,gocyclo
+func (r *KamajiControlPlaneReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) { //nolint:funlen,cyclop,maintidx,gocognit,gocyclo
 	var err error
 
 	now, log := time.Now(), ctrllog.FromContext(ctx)
@@ -66,6 +107,8 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if k8serrors.IsNotFound(err) {
 			log.Info("resource may have been deleted")
 
+			r.healthProbeSchedule.forget(req.NamespacedName)
+
 			return ctrl.Result{}, nil
 		}
 
@@ -102,7 +145,7 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if annotations.IsPaused(&cluster, &kcp) {
 		log.Info("Reconciliation is paused for this object")
 
-		conditions := kcp.Status.Conditions
+		conditions, previousConditions := kcp.Status.Conditions, slices.Clone(kcp.Status.Conditions)
 
 		meta.SetStatusCondition(&conditions, metav1.Condition{
 			Type:               string(kcpv1alpha2.PausedConditionType),
@@ -115,6 +158,8 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			kcp.Status.Conditions = conditions
 		}); updateErr != nil {
 			log.Error(updateErr, "unable to update Paused condition")
+		} else {
+			recordConditionEvents(r.recorder, &kcp, previousConditions, conditions)
 		}
 
 		return ctrl.Result{}, nil
@@ -126,18 +171,58 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, r.handleDeletion(ctx, kcp)
 	}
 
-	// Extracting conditions, used to update the KamajiControlPlane ones upon the end of the reconciliation.
-	conditions := kcp.Status.Conditions
+	// Extracting conditions, used to update the KamajiControlPlane ones upon the end of the reconciliation:
+	// the previous ones are kept to record the transitions as Events.
+	conditions, previousConditions := kcp.Status.Conditions, slices.Clone(kcp.Status.Conditions)
+	// Tracking the Cluster generation propagated to the TenantControlPlane, such as the cluster network.
+	observedClusterGeneration := kcp.Status.ObservedClusterGeneration
+	// Tracking the TenantControlPlane spec changes, telling apart the status reported by Kamaji before them.
+	observedTenantControlPlaneGeneration, tenantControlPlaneUpdateTime := kcp.Status.ObservedTenantControlPlaneGeneration, kcp.Status.TenantControlPlaneUpdateTime
+	// Tracking the replication of the Cluster API Secrets.
+	secrets := slices.Clone(kcp.Status.Secrets)
+	// Tracking the expiration of the Tenant Control Plane certificates.
+	certificates := slices.Clone(kcp.Status.Certificates)
+	// Tracking the delivery of the addon bundles to the workload cluster.
+	addonBundles := slices.Clone(kcp.Status.AddonBundles)
+	// Tracking the health of the Tenant Control Plane API server.
+	health := kcp.Status.Health
+	// Tracking the kubelet versions of the workload cluster Nodes.
+	nodeVersions := kcp.Status.NodeVersions
+	// Tracking the decisions of the HorizontalPodAutoscaler managing the replicas.
+	autoscaling := kcp.Status.Autoscaling
+	// Tracking the usage of the Tenant Control Plane components, and the requests recommended from it.
+	resourceRecommendations := kcp.Status.ResourceRecommendations
+	// Tracking the first time the KamajiControlPlane is reported as Available, observed once persisted.
+	firstAvailableTime, observeTimeToAvailable := kcp.Status.FirstAvailableTime, false
 
 	meta.RemoveStatusCondition(&conditions, string(kcpv1alpha2.PausedConditionType))
 
 	defer func() {
 		deferErr := r.updateKamajiControlPlaneStatus(ctx, &kcp, func() {
 			kcp.Status.Conditions = conditions
+			kcp.Status.ObservedClusterGeneration = observedClusterGeneration
+			kcp.Status.ObservedTenantControlPlaneGeneration = observedTenantControlPlaneGeneration
+			kcp.Status.TenantControlPlaneUpdateTime = tenantControlPlaneUpdateTime
+			kcp.Status.Secrets = secrets
+			kcp.Status.Certificates = certificates
+			kcp.Status.AddonBundles = addonBundles
+			kcp.Status.Health = health
+			kcp.Status.NodeVersions = nodeVersions
+			kcp.Status.Autoscaling = autoscaling
+			kcp.Status.ResourceRecommendations = resourceRecommendations
+			kcp.Status.FirstAvailableTime = firstAvailableTime
 		})
 
 		if deferErr != nil {
 			log.Error(err, "unable to update kcpv1alpha2.KamajiControlPlane conditions")
+
+			return
+		}
+
+		recordConditionEvents(r.recorder, &kcp, previousConditions, conditions)
+
+		if observeTimeToAvailable {
+			metrics.TimeToAvailable.Observe(firstAvailableTime.Sub(kcp.CreationTimestamp.Time).Seconds())
 		}
 	}()
 	// When ExternalClusterReference feature is enabled, we need to interact with a different API endpoint
@@ -164,11 +249,76 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			return ctrl.Result{}, err
 		}
 	}
+	// A failed upgrade has been rolled back to the last known-good TenantControlPlane spec:
+	// no further changes are pushed until the failure is acknowledged.
+	if kcp.Status.Upgrade.IsFailed() {
+		if _, ok := kcp.GetAnnotations()[kcpv1alpha2.UpgradeFailureAcknowledgedAnnotation]; !ok {
+			log.Info("upgrade failed, waiting for acknowledgement")
+
+			return r.handleUpgradeFailure(ctx, remoteClient, &kcp, &conditions)
+		}
+
+		if err = r.acknowledgeUpgradeFailure(ctx, &kcp); err != nil {
+			log.Error(err, "unable to acknowledge the upgrade failure")
+
+			return ctrl.Result{}, err
+		}
+
+		log.Info("upgrade failure has been acknowledged")
+	}
+	// Summarising the workload cluster Node versions, once the replicated admin kubeconfig is available:
+	// these are listed periodically, and used to hold upgrades breaking the version skew.
+	var nodesRequeueAfter time.Duration
+
+	if kcp.Status.IsControlPlaneInitialized() {
+		nodesCtx, nodesSpan := tracing.Start(ctx, "Nodes.Versions")
+		nodesRequeueAfter = r.reconcileNodeVersions(nodesCtx, cluster, kcp, &nodeVersions, &conditions)
+		tracing.End(nodesSpan, nil)
+	}
+	// Ensuring the desired state can be safely pushed to the running TenantControlPlane:
+	// in case of violation, the TenantControlPlane is left untouched until the KamajiControlPlane is amended.
+	if err = r.checkUpdateConstraints(ctx, remoteClient, kcp, nodeVersions); err != nil {
+		var violationErr UpdateConstraintViolationError
+		if !errors.As(err, &violationErr) {
+			log.Error(err, "unable to check update constraints")
+
+			return ctrl.Result{}, err
+		}
+
+		log.Info("update constraints not satisfied, TenantControlPlane will not be updated", "reason", violationErr.Reason, "message", violationErr.Message)
+
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.UpdateConstraintsSatisfiedConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             violationErr.Reason,
+			Message:            violationErr.Message,
+			ObservedGeneration: kcp.Generation,
+		})
+		// The workload cluster Nodes are not watched: checking again their versions, since they could be upgraded.
+		if violationErr.Reason == "NodeVersionSkew" {
+			return ctrl.Result{RequeueAfter: max(nodesRequeueAfter, time.Second)}, nil
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               string(kcpv1alpha2.UpdateConstraintsSatisfiedConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "Satisfied",
+		ObservedGeneration: kcp.Generation,
+	})
 	// Reconciling the Kamaji TenantControlPlane resource
 	var tcp *kamajiv1alpha1.TenantControlPlane
 
+	var drifted []string
+	// Taken before the update, since Kamaji could report the TenantControlPlane status in the meanwhile.
+	updateTime := metav1.Now()
+
 	TrackConditionType(&conditions, kcpv1alpha2.TenantControlPlaneCreatedConditionType, kcp.Generation, func() error {
-		tcp, err = r.createOrUpdateTenantControlPlane(ctx, remoteClient, cluster, kcp)
+		spanCtx, span := tracing.Start(ctx, "TenantControlPlane.CreateOrUpdate")
+		tcp, drifted, err = r.createOrUpdateTenantControlPlane(spanCtx, remoteClient, cluster, kcp)
+		tracing.End(span, err)
 
 		return err
 	})
@@ -178,6 +328,26 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{}, err
 	}
+
+	setTenantControlPlaneInSyncCondition(kcp, drifted, &conditions)
+
+	if len(drifted) > 0 {
+		log.Info("TenantControlPlane drifted from the KamajiControlPlane", "fields", drifted, "policy", kcp.Spec.DriftPolicy)
+
+		if kcp.Spec.DriftPolicy != kcpv1alpha2.DriftPolicyReport {
+			r.recorder.Eventf(&kcp, tcp, corev1.EventTypeWarning, EventReasonDriftReverted, EventActionPatch, "drifted fields reverted: %s", strings.Join(drifted, ", "))
+		}
+	}
+
+	if observedClusterGeneration != cluster.Generation {
+		log.Info("capiv1beta2.Cluster changes propagated to the TenantControlPlane", "generation", cluster.Generation)
+
+		observedClusterGeneration = cluster.Generation
+	}
+
+	if observedTenantControlPlaneGeneration != tcp.Generation {
+		observedTenantControlPlaneGeneration, tenantControlPlaneUpdateTime = tcp.Generation, &updateTime
+	}
 	// Waiting for the TenantControlPlane address: pay attention!
 	//
 	// This is still a work-in-progress and changing the Control Plane Controller contract.
@@ -197,13 +367,15 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if err != nil {
 		log.Info(err.Error() + ", enqueuing back")
 
-		return ctrl.Result{RequeueAfter: time.Second}, nil
+		return r.requeueWithBackoff(req), nil
 	}
 	// Starting from CAPI v1.8, the ControlPlane provider can set the Control Plane endpoint:
 	// this will make useless the patchCluster function in the future.
 	// More info: https://release-1-8.cluster-api.sigs.k8s.io/developer/providers/control-plane#optional-spec-fields-for-implementations-providing-endpoints
 	TrackConditionType(&conditions, kcpv1alpha2.ControlPlaneEndpointPatchedConditionType, kcp.Generation, func() error {
-		err = r.patchControlPlaneEndpoint(ctx, &kcp, tcp.Status.ControlPlaneEndpoint)
+		spanCtx, span := tracing.Start(ctx, "ControlPlaneEndpoint.Patch")
+		err = r.patchControlPlaneEndpoint(spanCtx, &kcp, tcp.Status.ControlPlaneEndpoint)
+		tracing.End(span, err)
 
 		return err
 	})
@@ -234,7 +406,9 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		// Patching the Infrastructure Cluster:
 		// this will be removed on the upcoming Kamaji Control Plane versions.
 		TrackConditionType(&conditions, kcpv1alpha2.InfrastructureClusterPatchedConditionType, kcp.Generation, func() error {
-			err = r.patchCluster(ctx, cluster, &kcp, tcp.Status.ControlPlaneEndpoint)
+			spanCtx, span := tracing.Start(ctx, "InfrastructureCluster.Patch", attribute.String("k8s.kind", cluster.Spec.InfrastructureRef.Kind))
+			err = r.patchCluster(spanCtx, cluster, &kcp, tcp.Status.ControlPlaneEndpoint)
+			tracing.End(span, err)
 
 			return err
 		})
@@ -242,6 +416,11 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if err != nil {
 			log.Error(err, "cannot patch capiv1beta2.Cluster")
 
+			metrics.InfrastructureClusterPatchFailures.WithLabelValues(cluster.Spec.InfrastructureRef.Kind).Inc()
+
+			r.recorder.Eventf(&kcp, &cluster, corev1.EventTypeWarning, EventReasonInfrastructureClusterPatchFailed, EventActionPatch,
+				"cannot patch %s %s: %s", cluster.Spec.InfrastructureRef.Kind, cluster.Spec.InfrastructureRef.Name, err.Error())
+
 			return ctrl.Result{}, err
 		}
 	}
@@ -252,19 +431,19 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if len(cluster.Spec.ControlPlaneEndpoint.Host) == 0 {
 		log.Info("capiv1beta2.Cluster Control Plane endpoint still unprocessed, enqueuing back")
 
-		return ctrl.Result{RequeueAfter: time.Second}, nil
+		return r.requeueWithBackoff(req), nil
 	}
 
 	if !ptr.Deref(cluster.Status.Initialization.InfrastructureProvisioned, false) {
 		log.Info("capiv1beta2.Cluster infrastructure is not yet provisioned, enqueuing back")
 
-		return ctrl.Result{RequeueAfter: time.Second}, nil
+		return r.requeueWithBackoff(req), nil
 	}
 
 	if tcp.Status.Kubernetes.Version.Status == nil {
 		log.Info("kamajiv1alpha1.TenantControlPlane is not yet initialized, enqueuing back")
 
-		return ctrl.Result{RequeueAfter: time.Second}, nil
+		return r.requeueWithBackoff(req), nil
 	}
 
 	if *tcp.Status.Kubernetes.Version.Status == kamajiv1alpha1.VersionReady && !kcp.Status.IsControlPlaneInitialized() {
@@ -287,10 +466,12 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if !kcp.Status.IsControlPlaneInitialized() {
 		log.Info("kcpv1alpha2.KamajiControlPlane is not yet initialized, enqueuing back")
 
-		return ctrl.Result{RequeueAfter: time.Second}, nil
+		return r.requeueWithBackoff(req), nil
 	}
 
 	// Updating KamajiControlPlane ready status, along with scaling values
+	var upgradeEnded bool
+
 	TrackConditionType(&conditions, kcpv1alpha2.KamajiControlPlaneInitializedConditionType, kcp.Generation, func() error {
 		err = r.updateKamajiControlPlaneStatus(ctx, &kcp, func() {
 			kcp.Status.ReadyReplicas = ptr.To(tcp.Status.Kubernetes.Deployment.ReadyReplicas)
@@ -298,7 +479,9 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			kcp.Status.Selector = ptr.To(tcp.Status.Kubernetes.Deployment.Selector)
 			kcp.Status.AvailableReplicas = ptr.To(tcp.Status.Kubernetes.Deployment.AvailableReplicas)
 			kcp.Status.UpToDateReplicas = ptr.To(tcp.Status.Kubernetes.Deployment.UpdatedReplicas)
-			kcp.Status.Version = tcp.Status.Kubernetes.Version.Version
+
+			upgradeEnded = reconcileUpgradeStatus(&kcp, tcp, &conditions)
+			reconcileRolloutStatus(&kcp, tcp, &conditions)
 		})
 
 		return err
@@ -309,6 +492,37 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{}, err
 	}
+	// Observed once the status has been updated, since the modifier is executed again upon conflicts.
+	if upgradeEnded {
+		observeUpgradeDuration(kcp.Status.Upgrade)
+	}
+
+	if kcp.Status.Upgrade.IsFailed() {
+		log.Info("upgrade exceeded the progress deadline, rolling back")
+
+		return r.handleUpgradeFailure(ctx, remoteClient, &kcp, &conditions)
+	}
+
+	var result ctrl.Result
+	// Scheduled rollouts must be triggered even if no further events are received.
+	if rolloutAfter := kcp.Spec.RolloutAfter; rolloutAfter != nil && rolloutAfter.After(time.Now()) {
+		result.RequeueAfter = time.Until(rolloutAfter.Time)
+	}
+	// Storing the last known-good state of a TenantControlPlane proven healthy, used to roll back failed upgrades:
+	// while upgrading, the deadline must be checked even if no further events are received.
+	if kcp.Spec.UpgradePolicy != nil {
+		if deadline, ok := upgradeDeadline(&kcp); ok {
+			if requeueAfter := max(time.Until(deadline), time.Second); result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+				result.RequeueAfter = requeueAfter
+			}
+		} else if isTenantControlPlaneKnownGood(tcp, tenantControlPlaneUpdateTime) {
+			if err = r.snapshotTenantControlPlane(ctx, kcp, tcp); err != nil {
+				log.Error(err, "unable to store the TenantControlPlane snapshot")
+
+				return ctrl.Result{}, err
+			}
+		}
+	}
 	// KamajiControlPlane must be considered ready before replicating required resources
 	TrackConditionType(&conditions, kcpv1alpha2.KamajiControlPlaneInitializedConditionType, kcp.Generation, func() error {
 		err = r.updateKamajiControlPlaneStatus(ctx, &kcp, func() {
@@ -318,10 +532,10 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return err
 	})
 
-	var result ctrl.Result
-
 	TrackConditionType(&conditions, kcpv1alpha2.KubeadmResourcesCreatedReadyConditionType, kcp.Generation, func() error {
-		err = r.createRequiredResources(ctx, remoteClient, cluster, kcp, tcp)
+		spanCtx, span := tracing.Start(ctx, "Secrets.Replicate")
+		err = r.createRequiredResources(spanCtx, remoteClient, cluster, kcp, tcp, &secrets)
+		tracing.End(span, err)
 
 		return err
 	})
@@ -330,13 +544,84 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if errors.Is(err, ErrEnqueueBack) {
 			log.Info(err.Error())
 
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+			return r.requeueWithBackoff(req), nil
 		}
 
 		log.Error(err, "unable to satisfy Secrets contract")
 
 		return ctrl.Result{}, err
 	}
+	// The Cluster API Secrets are replicated again once Kamaji regenerates the certificates, since the Kamaji Secrets are watched.
+	if _, ok := kcp.GetAnnotations()[kcpv1alpha2.RotateCertificatesAnnotation]; ok {
+		if err = r.rotateCertificates(ctx, remoteClient, &kcp, tcp); err != nil {
+			log.Error(err, "unable to request the certificates rotation")
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	if requeueAfter := r.reconcileCertificatesExpiry(ctx, remoteClient, kcp, tcp, &certificates, &conditions); requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = requeueAfter
+	}
+
+	var kubeconfigsRequeueAfter time.Duration
+
+	TrackConditionType(&conditions, kcpv1alpha2.KubeconfigsIssuedConditionType, kcp.Generation, func() error {
+		spanCtx, span := tracing.Start(ctx, "Kubeconfigs.Issue")
+		kubeconfigsRequeueAfter, err = r.reconcileKubeconfigs(spanCtx, remoteClient, cluster, kcp, tcp)
+		tracing.End(span, err)
+
+		return err
+	})
+
+	if err != nil {
+		log.Error(err, "unable to issue the declared kubeconfigs")
+
+		return ctrl.Result{}, err
+	}
+
+	if kubeconfigsRequeueAfter > 0 && (result.RequeueAfter == 0 || kubeconfigsRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = kubeconfigsRequeueAfter
+	}
+	// Addon bundles are applied through the replicated kubeconfig, once the control plane has been initialized.
+	addonsCtx, addonsSpan := tracing.Start(ctx, "AddonBundles.Apply")
+	addonsRequeueAfter := r.reconcileAddonBundles(addonsCtx, cluster, kcp, &addonBundles, &conditions)
+	tracing.End(addonsSpan, nil)
+
+	if addonsRequeueAfter > 0 && (result.RequeueAfter == 0 || addonsRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = addonsRequeueAfter
+	}
+
+	autoscalingCtx, autoscalingSpan := tracing.Start(ctx, "HorizontalPodAutoscaler.Reconcile")
+	err = r.reconcileAutoscaling(autoscalingCtx, remoteClient, kcp, &autoscaling, &conditions)
+	tracing.End(autoscalingSpan, err)
+
+	if err != nil {
+		log.Error(err, "unable to reconcile the HorizontalPodAutoscaler")
+
+		return ctrl.Result{}, err
+	}
+
+	recommendationsCtx, recommendationsSpan := tracing.Start(ctx, "ResourceRecommendations.Reconcile")
+	recommendationsRequeueAfter := r.reconcileResourceRecommendations(recommendationsCtx, remoteClient, kcp, tcp, &resourceRecommendations, &conditions)
+	tracing.End(recommendationsSpan, nil)
+
+	if recommendationsRequeueAfter > 0 && (result.RequeueAfter == 0 || recommendationsRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = recommendationsRequeueAfter
+	}
+	// The TenantControlPlane Deployment could look fine even though the API server is not answering,
+	// or reports failing checks such as the DataStore one: probing it through the replicated admin kubeconfig.
+	healthCtx, healthSpan := tracing.Start(ctx, "ControlPlane.Probe")
+	healthy, healthRequeueAfter := r.reconcileControlPlaneHealth(healthCtx, cluster, kcp, &health, &conditions)
+	tracing.End(healthSpan, nil)
+
+	if healthRequeueAfter > 0 && (result.RequeueAfter == 0 || healthRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = healthRequeueAfter
+	}
+
+	if nodesRequeueAfter > 0 && (result.RequeueAfter == 0 || nodesRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = nodesRequeueAfter
+	}
 
 	TrackConditionType(&conditions, kcpv1alpha2.KamajiControlPlaneReadyConditionType, kcp.Generation, func() error {
 		err = r.updateKamajiControlPlaneStatus(ctx, &kcp, func() {
@@ -354,9 +639,24 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	})
 
 	availableStatus, availableReason := metav1.ConditionFalse, "NotAvailable"
-	if kcp.Status.Ready {
+
+	switch {
+	case kcp.Status.Ready && !healthy:
+		availableReason = "ControlPlaneUnhealthy"
+	case kcp.Status.Ready:
 		availableStatus, availableReason = metav1.ConditionTrue, "Available"
 	}
+	// Tracking the provisioning time when the Available condition is reported as true for the first time:
+	// it could be reported as false beforehand, such as with an unhealthy API server.
+	if availableStatus == metav1.ConditionTrue && firstAvailableTime == nil {
+		firstAvailableTime = ptr.To(metav1.Now())
+		// The KamajiControlPlanes available before the first time has been tracked are not observed.
+		if available := meta.FindStatusCondition(previousConditions, string(kcpv1alpha2.AvailableConditionType)); available != nil && available.Status == metav1.ConditionTrue {
+			firstAvailableTime = ptr.To(available.LastTransitionTime)
+		} else {
+			observeTimeToAvailable = true
+		}
+	}
 
 	meta.SetStatusCondition(&conditions, metav1.Condition{
 		Type:               string(kcpv1alpha2.AvailableConditionType),
@@ -369,7 +669,7 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if errors.Is(err, ErrEnqueueBack) {
 			log.Info(err.Error())
 
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+			return r.requeueWithBackoff(req), nil
 		}
 
 		log.Error(err, "unable to report kcpv1alpha2.KamajiControlPlane readiness")
@@ -382,6 +682,26 @@ func (r *KamajiControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return result, nil
 }
 
+// removeKamajiControlPlaneAnnotation removes an annotation processed by the controller, such as a user request.
+func (r *KamajiControlPlaneReconciler) removeKamajiControlPlaneAnnotation(ctx context.Context, kcp *kcpv1alpha2.KamajiControlPlane, key string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(kcp), kcp); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		annotations := kcp.GetAnnotations()
+		delete(annotations, key)
+		kcp.SetAnnotations(annotations)
+
+		return r.client.Update(ctx, kcp)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUpdate, err.Error())
+	}
+
+	return nil
+}
+
 func (r *KamajiControlPlaneReconciler) updateKamajiControlPlaneStatus(ctx context.Context, kcp *kcpv1alpha2.KamajiControlPlane, modifierFn func()) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		if err := r.client.Get(ctx, client.ObjectKeyFromObject(kcp), kcp); err != nil {
@@ -402,16 +722,41 @@ func (r *KamajiControlPlaneReconciler) updateKamajiControlPlaneStatus(ctx contex
 // SetupWithManager sets up the controller with the Manager.
 func (r *KamajiControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, channel chan event.GenericEvent) error {
 	r.client = mgr.GetClient()
+	r.apiReader = mgr.GetAPIReader()
 	r.restMapper = mgr.GetRESTMapper()
+	r.recorder = mgr.GetEventRecorder("kamaji-control-plane-controller")
+	r.waitBackoff = newWaitBackoff(r.RequeueBaseDelay, r.RequeueMaxDelay, clock.RealClock{})
+	r.healthProbeSchedule = newHealthProbeSchedule(clock.RealClock{})
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&kcpv1alpha2.KamajiControlPlane{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
 			return len(object.GetOwnerReferences()) > 0
 		}))).
 		Owns(&corev1.Secret{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}, builder.WithPredicates(horizontalPodAutoscalerChanged())).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(tenantControlPlaneSecretToOwner)).
+		Watches(&capiv1beta2.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.clusterToKamajiControlPlanes), builder.WithPredicates(clusterChanged())).
+		// Watching the ConfigMaps metadata only, rather than caching the content of every ConfigMap in the cluster.
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.addonBundleSourceToKamajiControlPlanes("ConfigMap")), builder.OnlyMetadata).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.addonBundleSourceToKamajiControlPlanes("Secret"))).
 		WatchesRawSource(source.Channel(channel, &handler.EnqueueRequestForObject{})).
+		WatchesRawSource(r.ClusterCache.GetClusterSource("kamajicontrolplane", r.clusterToKamajiControlPlanes)).
 		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		WithEventFilter(predicates.ResourceNotPaused(mgr.GetScheme(), ctrl.LoggerFrom(ctx)))
 
+	clusterIndexer := indexers.KamajiControlPlaneCluster{}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, clusterIndexer.Object(), clusterIndexer.Field(), clusterIndexer.ExtractValue()); err != nil {
+		return fmt.Errorf("failed to set up indexer %s: %w", clusterIndexer.Field(), err)
+	}
+
+	addonBundleIndexer := indexers.KamajiControlPlaneAddonBundle{}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, addonBundleIndexer.Object(), addonBundleIndexer.Field(), addonBundleIndexer.ExtractValue()); err != nil {
+		return fmt.Errorf("failed to set up indexer %s: %w", addonBundleIndexer.Field(), err)
+	}
+
+	if err := metrics.RegisterCollectors(mgr.GetClient(), r.ExternalClusterReferenceStore.Len); err != nil {
+		return fmt.Errorf("%w: %s", ErrMetricsRegistration, err.Error())
+	}
+
 	cs, csErr := kubernetes.NewForConfig(mgr.GetConfig())
 	if csErr != nil {
 		return fmt.Errorf("%w: %s", ErrClientSetCreation, csErr.Error())
@@ -0,0 +1,240 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/indexers"
+)
+
+const (
+	// addonBundleFieldOwner is the field manager of the workload cluster resources applied from the addon bundles.
+	addonBundleFieldOwner = "kamaji-control-plane-provider"
+	// addonBundleResyncPeriod is the period the addon bundles in Reconcile mode are applied again to revert drifts.
+	addonBundleResyncPeriod = 10 * time.Minute
+	// addonBundleRetryPeriod is the delay before applying again the addon bundles which failed.
+	addonBundleRetryPeriod = 30 * time.Second
+)
+
+//+kubebuilder:rbac:groups="",resources="configmaps",verbs=get;list;watch
+
+// addonBundleManifests decodes the objects stored in the ConfigMap or Secret referenced by the addon bundle,
+// returning them along with the checksum of the manifests.
+// The ConfigMaps are only watched by their metadata, thus retrieved with no cache.
+func (r *KamajiControlPlaneReconciler) addonBundleManifests(ctx context.Context, kcp kcpv1alpha2.KamajiControlPlane, bundle kcpv1alpha2.AddonBundle) ([]*unstructured.Unstructured, string, error) {
+	data := map[string][]byte{}
+
+	switch bundle.Kind {
+	case "Secret":
+		var secret corev1.Secret
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: kcp.Namespace, Name: bundle.ResourceName}, &secret); err != nil {
+			return nil, "", errors.Wrapf(err, "cannot retrieve Secret %s", bundle.ResourceName)
+		}
+
+		maps.Copy(data, secret.Data)
+	default:
+		var configMap corev1.ConfigMap
+		if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: kcp.Namespace, Name: bundle.ResourceName}, &configMap); err != nil {
+			return nil, "", errors.Wrapf(err, "cannot retrieve ConfigMap %s", bundle.ResourceName)
+		}
+
+		for key, value := range configMap.Data {
+			data[key] = []byte(value)
+		}
+
+		maps.Copy(data, configMap.BinaryData)
+	}
+
+	keys := slices.Sorted(maps.Keys(data))
+
+	values, objects := make([][]byte, 0, len(keys)), make([]*unstructured.Unstructured, 0, len(keys))
+
+	for _, key := range keys {
+		values = append(values, data[key])
+
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data[key]), 4096) //nolint:mnd
+
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				return nil, "", errors.Wrapf(err, "cannot decode manifests from key %s", key)
+			}
+
+			if len(obj.Object) == 0 {
+				continue
+			}
+
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects, secretChecksum(values...), nil
+}
+
+// reconcileAddonBundles applies the addon bundles to the workload cluster with server-side apply, reporting the
+// outcome per bundle. It returns the delay before the addon bundles must be applied again, if any.
+//
+//nolint:funlen,cyclop
+func (r *KamajiControlPlaneReconciler) reconcileAddonBundles(ctx context.Context, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane, bundles *[]kcpv1alpha2.KamajiControlPlaneAddonBundleStatus, conditions *[]metav1.Condition) time.Duration {
+	log := ctrllog.FromContext(ctx)
+
+	if len(kcp.Spec.AddonBundles) == 0 {
+		*bundles = nil
+		meta.RemoveStatusCondition(conditions, string(kcpv1alpha2.AddonBundlesAppliedConditionType))
+
+		return 0
+	}
+
+	var (
+		workloadClient client.Client
+		requeueAfter   time.Duration
+		failures       []string
+	)
+
+	requeue := func(after time.Duration) {
+		if requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+	}
+
+	statuses := make([]kcpv1alpha2.KamajiControlPlaneAddonBundleStatus, 0, len(kcp.Spec.AddonBundles))
+
+	for _, bundle := range kcp.Spec.AddonBundles {
+		status := kcpv1alpha2.KamajiControlPlaneAddonBundleStatus{Name: bundle.Name}
+		if index := slices.IndexFunc(*bundles, func(s kcpv1alpha2.KamajiControlPlaneAddonBundleStatus) bool { return s.Name == bundle.Name }); index >= 0 {
+			status = *(*bundles)[index].DeepCopy()
+		}
+
+		err := func() error {
+			objects, checksum, err := r.addonBundleManifests(ctx, kcp, bundle)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case bundle.Mode == kcpv1alpha2.AddonBundleModeApplyOnce && status.Checksum != "":
+				return nil
+			case bundle.Mode != kcpv1alpha2.AddonBundleModeApplyOnce && status.Checksum == checksum && status.LastAppliedTime != nil:
+				if resyncAfter := time.Until(status.LastAppliedTime.Add(addonBundleResyncPeriod)); resyncAfter > 0 {
+					requeue(resyncAfter)
+
+					return nil
+				}
+			}
+
+			if workloadClient == nil {
+				if workloadClient, err = r.ClusterCache.GetClient(ctx, client.ObjectKeyFromObject(&cluster)); err != nil {
+					return errors.Wrap(err, "cannot retrieve workload cluster client")
+				}
+			}
+
+			for _, obj := range objects {
+				if err = workloadClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(obj), client.FieldOwner(addonBundleFieldOwner), client.ForceOwnership); err != nil {
+					return errors.Wrapf(err, "cannot apply %s %s", obj.GetKind(), client.ObjectKeyFromObject(obj).String())
+				}
+			}
+
+			status.Checksum, status.LastAppliedTime = checksum, ptr.To(metav1.Now())
+
+			log.Info("addon bundle applied", "bundle", bundle.Name, "objects", len(objects))
+
+			r.recorder.Eventf(&kcp, nil, corev1.EventTypeNormal, EventReasonAddonBundleApplied, EventActionApply, "addon bundle %s applied, %d objects", bundle.Name, len(objects))
+
+			if bundle.Mode != kcpv1alpha2.AddonBundleModeApplyOnce {
+				requeue(addonBundleResyncPeriod)
+			}
+
+			return nil
+		}()
+
+		condition := metav1.Condition{
+			Type:               kcpv1alpha2.AddonBundleAppliedConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Applied",
+			ObservedGeneration: kcp.Generation,
+		}
+
+		if err != nil {
+			log.Error(err, "unable to apply addon bundle", "bundle", bundle.Name)
+
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "ApplyFailed", err.Error()
+
+			failures = append(failures, bundle.Name)
+
+			requeue(addonBundleRetryPeriod)
+		}
+
+		meta.SetStatusCondition(&status.Conditions, condition)
+
+		statuses = append(statuses, status)
+	}
+
+	*bundles = statuses
+
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.AddonBundlesAppliedConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		ObservedGeneration: kcp.Generation,
+	}
+
+	if len(failures) > 0 {
+		condition.Status, condition.Reason = metav1.ConditionFalse, "ApplyFailed"
+		condition.Message = fmt.Sprintf("addon bundles not applied: %s", strings.Join(failures, ", "))
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+
+	return requeueAfter
+}
+
+// addonBundleSourceToKamajiControlPlanes maps the ConfigMaps or Secrets, according to the given kind,
+// to the KamajiControlPlane instances referencing them as addon bundle: the objects could be watched
+// by their metadata only, thus the kind is not inferred from them.
+func (r *KamajiControlPlaneReconciler) addonBundleSourceToKamajiControlPlanes(kind string) handler.MapFunc {
+	return func(ctx context.Context, object client.Object) []ctrl.Request {
+		var kcpList kcpv1alpha2.KamajiControlPlaneList
+
+		if err := r.client.List(ctx, &kcpList, client.InNamespace(object.GetNamespace()), client.MatchingFields{indexers.KamajiControlPlaneAddonBundleField: kind + "/" + object.GetName()}); err != nil {
+			ctrllog.FromContext(ctx).Error(err, "unable to list KamajiControlPlane referencing the addon bundle", "kind", kind, "name", object.GetName())
+
+			return nil
+		}
+
+		requests := make([]ctrl.Request, 0, len(kcpList.Items))
+
+		for _, kcp := range kcpList.Items {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: kcp.Namespace, Name: kcp.Name}})
+		}
+
+		return requests
+	}
+}
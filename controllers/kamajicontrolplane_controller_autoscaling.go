@@ -0,0 +1,181 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const defaultRequestRateMetricName = "apiserver_request_rate"
+
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+// horizontalPodAutoscalerMetrics translates the autoscaling targets into the HorizontalPodAutoscaler metrics:
+// the CPU utilization is computed on the pods selected by the KamajiControlPlane scale subresource selector.
+func horizontalPodAutoscalerMetrics(autoscaling kcpv1alpha2.AutoscalingSpec) []autoscalingv2.MetricSpec {
+	var metrics []autoscalingv2.MetricSpec
+
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: autoscaling.TargetCPUUtilizationPercentage,
+				},
+			},
+		})
+	}
+
+	if target := autoscaling.TargetRequestRate; target != nil {
+		metricName := target.MetricName
+		if metricName == "" {
+			metricName = defaultRequestRateMetricName
+		}
+
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: metricName},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: ptr.To(target.AverageValue.DeepCopy()),
+				},
+			},
+		})
+	}
+
+	return metrics
+}
+
+// deleteHorizontalPodAutoscaler removes the HorizontalPodAutoscaler managed for the KamajiControlPlane, if any.
+func (r *KamajiControlPlaneReconciler) deleteHorizontalPodAutoscaler(ctx context.Context, kcp kcpv1alpha2.KamajiControlPlane) error {
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(&kcp), &hpa); err != nil {
+		return client.IgnoreNotFound(err) //nolint:wrapcheck
+	}
+
+	if !metav1.IsControlledBy(&hpa, &kcp) {
+		return nil
+	}
+
+	if err := r.client.Delete(ctx, &hpa); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot delete HorizontalPodAutoscaler")
+	}
+
+	return nil
+}
+
+// reconcileAutoscaling manages the HorizontalPodAutoscaler targeting the KamajiControlPlane scale subresource,
+// reporting its decisions in status and its ScalingActive condition with the AutoscalingActive one.
+//
+//nolint:funlen
+func (r *KamajiControlPlaneReconciler) reconcileAutoscaling(ctx context.Context, remoteClient client.Client, kcp kcpv1alpha2.KamajiControlPlane, autoscaling **kcpv1alpha2.KamajiControlPlaneAutoscalingStatus, conditions *[]metav1.Condition) error {
+	if kcp.Spec.Autoscaling == nil || remoteClient != nil {
+		if err := r.deleteHorizontalPodAutoscaler(ctx, kcp); err != nil {
+			return err
+		}
+
+		*autoscaling = nil
+
+		if kcp.Spec.Autoscaling == nil {
+			meta.RemoveStatusCondition(conditions, string(kcpv1alpha2.AutoscalingActiveConditionType))
+
+			return nil
+		}
+		// The HorizontalPodAutoscaler runs in the management cluster, it cannot select the remote Tenant Control Plane pods.
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.AutoscalingActiveConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             "ExternalClusterReferenceUnsupported",
+			Message:            "autoscaling is not supported when the Tenant Control Plane is deployed with ExternalClusterReference",
+			ObservedGeneration: kcp.Generation,
+		})
+
+		return nil
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	hpa.Name, hpa.Namespace = kcp.Name, kcp.Namespace
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.client, hpa, func() error {
+		hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: kcpv1alpha2.GroupVersion.String(),
+			Kind:       "KamajiControlPlane",
+			Name:       kcp.Name,
+		}
+		hpa.Spec.MinReplicas = ptr.To(ptr.Deref(kcp.Spec.Autoscaling.MinReplicas, 1))
+		hpa.Spec.MaxReplicas = kcp.Spec.Autoscaling.MaxReplicas
+		hpa.Spec.Metrics = horizontalPodAutoscalerMetrics(*kcp.Spec.Autoscaling)
+
+		return controllerutil.SetControllerReference(&kcp, hpa, r.client.Scheme())
+	}); err != nil {
+		return errors.Wrap(err, "cannot create or update HorizontalPodAutoscaler")
+	}
+
+	status := &kcpv1alpha2.KamajiControlPlaneAutoscalingStatus{
+		HorizontalPodAutoscalerName: hpa.Name,
+		CurrentReplicas:             hpa.Status.CurrentReplicas,
+		DesiredReplicas:             hpa.Status.DesiredReplicas,
+		LastScaleTime:               hpa.Status.LastScaleTime,
+	}
+
+	for _, hpaCondition := range hpa.Status.Conditions {
+		// The reason is optional for the HorizontalPodAutoscaler conditions, although required by the metav1 ones.
+		reason := hpaCondition.Reason
+		if reason == "" {
+			reason = string(hpaCondition.Type)
+		}
+
+		status.Conditions = append(status.Conditions, metav1.Condition{
+			Type:               string(hpaCondition.Type),
+			Status:             metav1.ConditionStatus(hpaCondition.Status),
+			Reason:             reason,
+			Message:            hpaCondition.Message,
+			LastTransitionTime: hpaCondition.LastTransitionTime,
+			ObservedGeneration: kcp.Generation,
+		})
+	}
+	// Recording the scaling decisions taken since the last reconciliation.
+	if previous := *autoscaling; status.LastScaleTime != nil && (previous == nil || previous.LastScaleTime == nil || !previous.LastScaleTime.Equal(status.LastScaleTime)) {
+		note := fmt.Sprintf("replicas scaled to %d", status.DesiredReplicas)
+		if ableToScale := meta.FindStatusCondition(status.Conditions, string(autoscalingv2.AbleToScale)); ableToScale != nil && ableToScale.Message != "" {
+			note += ", " + ableToScale.Message
+		}
+
+		r.recorder.Eventf(&kcp, hpa, corev1.EventTypeNormal, EventReasonScaled, EventActionScale, "%s", note)
+	}
+
+	*autoscaling = status
+
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.AutoscalingActiveConditionType),
+		Status:             metav1.ConditionUnknown,
+		Reason:             "AutoscalerPending",
+		Message:            "waiting for the HorizontalPodAutoscaler to compute the replicas",
+		ObservedGeneration: kcp.Generation,
+	}
+
+	if scalingActive := meta.FindStatusCondition(status.Conditions, string(autoscalingv2.ScalingActive)); scalingActive != nil {
+		condition.Status, condition.Reason, condition.Message = scalingActive.Status, scalingActive.Reason, scalingActive.Message
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const (
+	defaultCertificatesExpiryWindow = 30 * 24 * time.Hour
+	// kamajiRotateCertificateAnnotation requests Kamaji to regenerate the certificate, or the kubeconfig,
+	// stored in the annotated Secret.
+	kamajiRotateCertificateAnnotation = "certs.kamaji.clastix.io/rotate"
+)
+
+var ErrMissingCertificate = errors.New("no PEM encoded certificate found")
+
+// tenantCertificate describes a Tenant Control Plane certificate whose expiration is tracked.
+type tenantCertificate struct {
+	name       string
+	secretName string
+	parse      func(secret *corev1.Secret) (*x509.Certificate, error)
+}
+
+// parseSecretCertificate returns the parser of a PEM encoded certificate stored in the given Secret key.
+func parseSecretCertificate(key string) func(secret *corev1.Secret) (*x509.Certificate, error) {
+	return func(secret *corev1.Secret) (*x509.Certificate, error) {
+		return parseCertificate(secret.Data[key])
+	}
+}
+
+// parseKubeconfigCertificate returns the parser of the client certificate used by the current context
+// of the kubeconfig stored in the given Secret key.
+func parseKubeconfigCertificate(key string) func(secret *corev1.Secret) (*x509.Certificate, error) {
+	return func(secret *corev1.Secret) (*x509.Certificate, error) {
+		config, err := clientcmd.Load(secret.Data[key])
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot load kubeconfig")
+		}
+
+		kubeContext, ok := config.Contexts[config.CurrentContext]
+		if !ok {
+			return nil, errors.Errorf("missing current context %q in kubeconfig", config.CurrentContext)
+		}
+
+		authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+		if !ok {
+			return nil, errors.Errorf("missing user %q in kubeconfig", kubeContext.AuthInfo)
+		}
+
+		return parseCertificate(authInfo.ClientCertificateData)
+	}
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, ErrMissingCertificate
+	}
+
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse certificate")
+	}
+
+	return crt, nil
+}
+
+// reconcileCertificatesExpiry publishes the expiration of the Tenant Control Plane certificates, reporting the
+// CertificatesValid condition as false when any of them is within the expiry window.
+// It returns the delay before the next certificate enters the expiry window, if any.
+//
+//nolint:cyclop
+func (r *KamajiControlPlaneReconciler) reconcileCertificatesExpiry(ctx context.Context, remoteClient client.Client, kcp kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, certificates *[]kcpv1alpha2.KamajiControlPlaneCertificateStatus, conditions *[]metav1.Condition) time.Duration {
+	log := ctrllog.FromContext(ctx)
+
+	reader := r.client
+
+	if remoteClient != nil {
+		reader = remoteClient
+	}
+
+	window := defaultCertificatesExpiryWindow
+	if kcp.Spec.CertificatesExpiryWindow != nil {
+		window = kcp.Spec.CertificatesExpiryWindow.Duration
+	}
+
+	var (
+		requeueAfter       time.Duration
+		expiring, failures []string
+	)
+
+	statuses := make([]kcpv1alpha2.KamajiControlPlaneCertificateStatus, 0, len(*certificates))
+
+	for _, certificate := range []tenantCertificate{
+		{
+			name:       "ca",
+			secretName: tcp.Status.Certificates.CA.SecretName,
+			parse:      parseSecretCertificate("ca.crt"),
+		},
+		{
+			name:       "apiServer",
+			secretName: tcp.Status.Certificates.APIServer.SecretName,
+			parse:      parseSecretCertificate("apiserver.crt"),
+		},
+		{
+			name:       "adminKubeconfig",
+			secretName: tcp.Status.KubeConfig.Admin.SecretName,
+			parse:      parseKubeconfigCertificate(adminKubeconfigSecretKey(kcp)),
+		},
+	} {
+		if len(certificate.secretName) == 0 {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+
+		crt, err := func() (*x509.Certificate, error) {
+			if err := reader.Get(ctx, types.NamespacedName{Name: certificate.secretName, Namespace: tcp.Namespace}, secret); err != nil {
+				return nil, errors.Wrap(err, "cannot retrieve Secret")
+			}
+
+			return certificate.parse(secret)
+		}()
+		if err != nil {
+			log.Error(err, "unable to track the certificate expiration", "certificate", certificate.name, "secret", certificate.secretName)
+
+			failures = append(failures, certificate.name+": "+err.Error())
+			// Retaining the last known expiration, if any.
+			if index := slices.IndexFunc(*certificates, func(status kcpv1alpha2.KamajiControlPlaneCertificateStatus) bool {
+				return status.Name == certificate.name
+			}); index >= 0 {
+				statuses = append(statuses, (*certificates)[index])
+			}
+
+			continue
+		}
+
+		statuses = append(statuses, kcpv1alpha2.KamajiControlPlaneCertificateStatus{
+			Name:       certificate.name,
+			SecretName: certificate.secretName,
+			NotAfter:   metav1.NewTime(crt.NotAfter),
+		})
+
+		untilWindow := time.Until(crt.NotAfter.Add(-window))
+		if untilWindow <= 0 {
+			expiring = append(expiring, fmt.Sprintf("%s expires at %s", certificate.name, crt.NotAfter.UTC().Format(time.RFC3339)))
+
+			continue
+		}
+
+		if requeueAfter == 0 || untilWindow < requeueAfter {
+			requeueAfter = untilWindow
+		}
+	}
+
+	*certificates = statuses
+
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.CertificatesValidConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "CertificatesValid",
+		ObservedGeneration: kcp.Generation,
+	}
+
+	switch {
+	case len(expiring) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CertificatesExpiring"
+		condition.Message = fmt.Sprintf("certificates expiring within %s, set the %s annotation to rotate them: %s", window.String(), kcpv1alpha2.RotateCertificatesAnnotation, strings.Join(expiring, ", "))
+	case len(failures) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "CertificatesUnknown"
+		condition.Message = strings.Join(failures, ", ")
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+
+	return requeueAfter
+}
+
+// rotateCertificates requests Kamaji to regenerate the Tenant Control Plane certificates and kubeconfigs,
+// except the Certificate Authorities, removing the annotation once processed.
+func (r *KamajiControlPlaneReconciler) rotateCertificates(ctx context.Context, remoteClient client.Client, kcp *kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	k8sClient := r.client
+
+	if remoteClient != nil {
+		k8sClient = remoteClient
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				kamajiRotateCertificateAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal rotation patch")
+	}
+
+	var rotated int
+
+	for _, secretName := range []string{
+		tcp.Status.Certificates.APIServer.SecretName,
+		tcp.Status.Certificates.APIServerKubeletClient.SecretName,
+		tcp.Status.Certificates.FrontProxyClient.SecretName,
+		tcp.Status.KubeConfig.Admin.SecretName,
+		tcp.Status.KubeConfig.ControllerManager.SecretName,
+		tcp.Status.KubeConfig.Scheduler.SecretName,
+	} {
+		if len(secretName) == 0 {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		secret.Name, secret.Namespace = secretName, tcp.Namespace
+
+		if err = k8sClient.Patch(ctx, secret, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return errors.Wrapf(err, "cannot request the rotation of Secret %s", secretName)
+		}
+
+		rotated++
+	}
+
+	if err = r.removeKamajiControlPlaneAnnotation(ctx, kcp, kcpv1alpha2.RotateCertificatesAnnotation); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(kcp, tcp, corev1.EventTypeNormal, EventReasonCertificatesRotationRequested, EventActionRotate, "rotation of %d certificates and kubeconfigs requested to Kamaji", rotated)
+
+	return nil
+}
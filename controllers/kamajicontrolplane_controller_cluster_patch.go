@@ -11,14 +11,19 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/retry"
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/contract"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/infrastructure"
 )
 
 func (r *KamajiControlPlaneReconciler) controlPlaneEndpoint(controlPlane *v1alpha2.KamajiControlPlane, statusEndpoint string) (string, int64, error) {
@@ -101,7 +106,38 @@ func (r *KamajiControlPlaneReconciler) getInfraClusterFromRef(ctx context.Contex
 	return obj, nil
 }
 
-//nolint:cyclop
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+
+// infrastructurePatchStrategy resolves the patch strategy of an InfraCluster kind from the configured ConfigMap,
+// the InfraCluster CustomResourceDefinition, and the dynamic infrastructure clusters: see infrastructure.Resolve.
+func (r *KamajiControlPlaneReconciler) infrastructurePatchStrategy(ctx context.Context, ref capiv1beta2.ContractVersionedObjectReference) (infrastructure.Strategy, bool, error) {
+	// Only the CustomResourceDefinition metadata is required, sparing the apiextensions types.
+	crd := &metav1.PartialObjectMetadata{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})
+	crd.SetName(contract.CalculateCRDName(ref.APIGroup, ref.Kind))
+
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(crd), crd); err != nil && !k8serrors.IsNotFound(err) {
+		return infrastructure.Strategy{}, false, errors.Wrapf(err, "cannot retrieve the %s CustomResourceDefinition", crd.GetName())
+	}
+
+	var declared infrastructure.Registry
+
+	if r.InfrastructurePatchStrategies.Name != "" {
+		// The ConfigMaps are not cached, since watched by their metadata only.
+		var configMap corev1.ConfigMap
+		if err := r.apiReader.Get(ctx, r.InfrastructurePatchStrategies, &configMap); err != nil {
+			return infrastructure.Strategy{}, false, errors.Wrapf(err, "cannot retrieve the infrastructure patch strategies ConfigMap %s", r.InfrastructurePatchStrategies.String())
+		}
+
+		var err error
+		if declared, err = infrastructure.ParseConfigMap(configMap); err != nil {
+			return infrastructure.Strategy{}, false, errors.Wrapf(err, "cannot parse the infrastructure patch strategies ConfigMap %s", r.InfrastructurePatchStrategies.String())
+		}
+	}
+
+	return infrastructure.Resolve(ref.Kind, declared, crd, r.DynamicInfrastructureClusters.Has(ref.Kind)) //nolint:wrapcheck
+}
+
 func (r *KamajiControlPlaneReconciler) patchCluster(ctx context.Context, cluster capiv1beta2.Cluster, controlPlane *v1alpha2.KamajiControlPlane, hostPort string) error {
 	if !cluster.Spec.InfrastructureRef.IsDefined() {
 		return errors.New("capiv1beta2.Cluster has no InfrastructureRef")
@@ -112,49 +148,32 @@ func (r *KamajiControlPlaneReconciler) patchCluster(ctx context.Context, cluster
 		return errors.Wrap(err, "cannot retrieve ControlPlaneEndpoint")
 	}
 
-	switch cluster.Spec.InfrastructureRef.Kind {
-	case "AWSCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, false)
-	case "AzureCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, false)
-	case "HetznerCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, false)
-	case "IonosCloudCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, false)
-	case "KubevirtCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, true)
-	case "Metal3Cluster":
-		return r.checkGenericCluster(ctx, cluster, endpoint, port)
-	case "MetalStackCluster":
-		return r.checkGenericCluster(ctx, cluster, endpoint, port)
-	case "NutanixCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, true)
-	case "OpenStackCluster":
-		return r.patchOpenStackCluster(ctx, cluster, endpoint, port)
-	case "PacketCluster":
-		return r.patchGenericCluster(ctx, cluster, endpoint, port, true)
-	case "ProxmoxCluster":
-		return r.checkOrPatchGenericCluster(ctx, cluster, endpoint, port)
-	case "TinkerbellCluster":
-		return r.checkOrPatchGenericCluster(ctx, cluster, endpoint, port)
-	case "VSphereCluster":
-		return r.checkOrPatchGenericCluster(ctx, cluster, endpoint, port)
-	default:
-		if r.DynamicInfrastructureClusters.Has(cluster.Spec.InfrastructureRef.Kind) {
-			return r.patchGenericCluster(ctx, cluster, endpoint, port, false)
-		}
+	strategy, ok, err := r.infrastructurePatchStrategy(ctx, cluster.Spec.InfrastructureRef)
+	if err != nil {
+		return err
+	}
 
+	if !ok {
 		return errors.New("unsupported infrastructure provider")
 	}
+
+	switch strategy.GetAction() {
+	case infrastructure.ActionCheck:
+		return r.checkGenericCluster(ctx, cluster, strategy, endpoint, port)
+	case infrastructure.ActionCheckOrPatch:
+		return r.checkOrPatchGenericCluster(ctx, cluster, strategy, endpoint, port)
+	default:
+		return r.patchGenericCluster(ctx, cluster, strategy, endpoint, port)
+	}
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=proxmoxclusters;vsphereclusters;tinkerbellclusters,verbs=get;list;watch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=proxmoxclusters;vsphereclusters;tinkerbellclusters,verbs=patch
 
-func (r *KamajiControlPlaneReconciler) checkOrPatchGenericCluster(ctx context.Context, cluster capiv1beta2.Cluster, endpoint string, port int64) error {
-	if err := r.checkGenericCluster(ctx, cluster, endpoint, port); err != nil {
+func (r *KamajiControlPlaneReconciler) checkOrPatchGenericCluster(ctx context.Context, cluster capiv1beta2.Cluster, strategy infrastructure.Strategy, endpoint string, port int64) error {
+	if err := r.checkGenericCluster(ctx, cluster, strategy, endpoint, port); err != nil {
 		if errors.As(err, &UnmanagedControlPlaneAddressError{}) {
-			return r.patchGenericCluster(ctx, cluster, endpoint, port, false)
+			return r.patchGenericCluster(ctx, cluster, strategy, endpoint, port)
 		}
 
 		return err
@@ -163,10 +182,10 @@ func (r *KamajiControlPlaneReconciler) checkOrPatchGenericCluster(ctx context.Co
 	return nil
 }
 
-//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters;azureclusters;hetznerclusters;kubevirtclusters;nutanixclusters;packetclusters;ionoscloudclusters,verbs=patch;get;list;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters;azureclusters;hetznerclusters;kubevirtclusters;nutanixclusters;packetclusters;ionoscloudclusters;openstackclusters,verbs=patch;get;list;watch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=kubevirtclusters/status;nutanixclusters/status;packetclusters/status,verbs=patch
 
-func (r *KamajiControlPlaneReconciler) patchGenericCluster(ctx context.Context, cluster capiv1beta2.Cluster, endpoint string, port int64, patchStatus bool) error {
+func (r *KamajiControlPlaneReconciler) patchGenericCluster(ctx context.Context, cluster capiv1beta2.Cluster, strategy infrastructure.Strategy, endpoint string, port int64) error {
 	infraCluster, err := r.getInfraClusterFromRef(ctx, cluster.Spec.InfrastructureRef, cluster.GetNamespace())
 	if err != nil {
 		return errors.Wrap(err, "cannot retrieve infrastructure cluster "+cluster.Spec.InfrastructureRef.Kind)
@@ -177,17 +196,8 @@ func (r *KamajiControlPlaneReconciler) patchGenericCluster(ctx context.Context,
 		return errors.Wrap(err, "unable to create patch helper")
 	}
 
-	if err = unstructured.SetNestedMap(infraCluster.Object, map[string]interface{}{
-		"host": endpoint,
-		"port": port,
-	}, "spec", "controlPlaneEndpoint"); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("unable to set unstructured %s spec patch", infraCluster.GetKind()))
-	}
-
-	if patchStatus {
-		if err = unstructured.SetNestedField(infraCluster.Object, true, "status", "ready"); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("unable to set unstructured %s status patch", infraCluster.GetKind()))
-		}
+	if err = strategy.SetEndpoint(infraCluster, endpoint, port); err != nil {
+		return errors.Wrap(err, "unable to set the control plane endpoint")
 	}
 
 	if err = patchHelper.Patch(ctx, infraCluster); err != nil {
@@ -199,30 +209,21 @@ func (r *KamajiControlPlaneReconciler) patchGenericCluster(ctx context.Context,
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3clusters;metalstackclusters,verbs=get;list;watch
 
-func (r *KamajiControlPlaneReconciler) checkGenericCluster(ctx context.Context, cluster capiv1beta2.Cluster, endpoint string, port int64) error {
+func (r *KamajiControlPlaneReconciler) checkGenericCluster(ctx context.Context, cluster capiv1beta2.Cluster, strategy infrastructure.Strategy, endpoint string, port int64) error {
 	gkc, err := r.getInfraClusterFromRef(ctx, cluster.Spec.InfrastructureRef, cluster.GetNamespace())
 	if err != nil {
 		return errors.Wrap(err, "cannot retrieve infrastructure cluster "+cluster.Spec.InfrastructureRef.Kind)
 	}
 
-	cpHost, _, err := unstructured.NestedString(gkc.Object, "spec", "controlPlaneEndpoint", "host")
+	cpHost, cpPort, err := strategy.Endpoint(gkc)
 	if err != nil {
-		return errors.Wrap(err, "cannot extract control plane endpoint host")
+		return errors.Wrap(err, "cannot extract control plane endpoint")
 	}
 
 	if cpHost == "" {
 		return *NewUnmanagedControlPlaneAddressError(gkc.GetKind())
 	}
 
-	cpPort, _, err := unstructured.NestedInt64(gkc.Object, "spec", "controlPlaneEndpoint", "port")
-	if err != nil {
-		return errors.Wrap(err, "cannot extract control plane endpoint host")
-	}
-
-	if len(cpHost) == 0 && cpPort == 0 {
-		return *NewUnmanagedControlPlaneAddressError(gkc.GetKind())
-	}
-
 	if cpHost != endpoint {
 		return fmt.Errorf("the %s cluster has been provisioned with a mismatching host", gkc.GetKind())
 	}
@@ -233,31 +234,3 @@ func (r *KamajiControlPlaneReconciler) checkGenericCluster(ctx context.Context,
 
 	return nil
 }
-
-//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=openstackclusters,verbs=patch;get;list;watch
-
-func (r *KamajiControlPlaneReconciler) patchOpenStackCluster(ctx context.Context, cluster capiv1beta2.Cluster, endpoint string, port int64) error {
-	osc, err := r.getInfraClusterFromRef(ctx, cluster.Spec.InfrastructureRef, cluster.GetNamespace())
-	if err != nil {
-		return errors.Wrap(err, "cannot retrieve infrastructure cluster "+cluster.Spec.InfrastructureRef.Kind)
-	}
-
-	patchHelper, err := patch.NewHelper(osc, r.client)
-	if err != nil {
-		return errors.Wrap(err, "unable to create patch helper")
-	}
-
-	if err = unstructured.SetNestedField(osc.Object, endpoint, "spec", "apiServerFixedIP"); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("unable to set unstructured %s spec apiServerFixedIP", osc.GetKind()))
-	}
-
-	if err = unstructured.SetNestedField(osc.Object, port, "spec", "apiServerPort"); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("unable to set unstructured %s spec apiServerPort", osc.GetKind()))
-	}
-
-	if err = patchHelper.Patch(ctx, osc); err != nil {
-		return errors.Wrap(err, "cannot perform PATCH update for the OpenStackCluster resource")
-	}
-
-	return nil
-}
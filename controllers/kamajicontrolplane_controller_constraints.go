@@ -0,0 +1,106 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/externalclusterreference"
+)
+
+// UpdateConstraintViolationError is returned when the desired KamajiControlPlane state cannot be pushed
+// to the running TenantControlPlane, such as skipping minor versions or changing immutable fields.
+type UpdateConstraintViolationError struct {
+	Reason  string
+	Message string
+}
+
+func (u UpdateConstraintViolationError) Error() string {
+	return u.Message
+}
+
+// checkUpdateConstraints compares the desired KamajiControlPlane state with the running TenantControlPlane:
+// the admission webhook is enforcing the same rules, although it could be disabled, or the KamajiControlPlane
+// could have been changed by the Cluster topology controller before the webhook was in place.
+func (r *KamajiControlPlaneReconciler) checkUpdateConstraints(ctx context.Context, remoteClient client.Client, kcp kcpv1alpha2.KamajiControlPlane, nodeVersions *kcpv1alpha2.KamajiControlPlaneNodeVersionsStatus) error {
+	k8sClient, tcp := r.client, &kamajiv1alpha1.TenantControlPlane{}
+	tcp.Name, tcp.Namespace = kcp.GetName(), kcp.GetNamespace()
+
+	if remoteClient != nil {
+		k8sClient = remoteClient
+		tcp.Name, tcp.Namespace = externalclusterreference.GenerateRemoteTenantControlPlaneNames(kcp)
+	}
+
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(tcp), tcp); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, "cannot retrieve TenantControlPlane")
+		}
+
+		if remoteClient == nil {
+			return nil
+		}
+
+		return r.checkExternalClusterReferencePlacement(ctx, remoteClient, kcp, tcp.Name, tcp.Namespace)
+	}
+
+	if err := kcpv1alpha2.ValidateVersionUpgrade(tcp.Spec.Kubernetes.Version, normalizeVersion(kcp.Spec.Version)); err != nil {
+		reason := "VersionSkew"
+		if errors.Is(err, kcpv1alpha2.ErrVersionDowngrade) {
+			reason = "VersionDowngrade"
+		}
+
+		return UpdateConstraintViolationError{Reason: reason, Message: err.Error()}
+	}
+
+	if tcp.Spec.Kubernetes.Version != normalizeVersion(kcp.Spec.Version) {
+		if err := checkNodeVersionSkew(kcp, nodeVersions); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := kcp.GetAnnotations()[kcpv1alpha2.MigrationAnnotation]; ok {
+		return nil
+	}
+
+	if tcp.Spec.DataStore != "" && kcp.Spec.DataStoreName != "" && tcp.Spec.DataStore != kcp.Spec.DataStoreName {
+		return UpdateConstraintViolationError{
+			Reason:  "ImmutableFieldChanged",
+			Message: fmt.Sprintf("dataStoreName cannot be changed from %s to %s without the %s annotation", tcp.Spec.DataStore, kcp.Spec.DataStoreName, kcpv1alpha2.MigrationAnnotation),
+		}
+	}
+
+	return nil
+}
+
+// checkExternalClusterReferencePlacement ensures the remote TenantControlPlane hasn't been already deployed
+// to a different Namespace, since changing the deployment Namespace would orphan the running instance.
+func (r *KamajiControlPlaneReconciler) checkExternalClusterReferencePlacement(ctx context.Context, remoteClient client.Client, kcp kcpv1alpha2.KamajiControlPlane, name, namespace string) error {
+	if _, ok := kcp.GetAnnotations()[kcpv1alpha2.MigrationAnnotation]; ok {
+		return nil
+	}
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+
+	if err := remoteClient.List(ctx, &tcpList); err != nil {
+		return errors.Wrap(err, "cannot list remote TenantControlPlane instances")
+	}
+
+	for _, tcp := range tcpList.Items {
+		if tcp.Name == name && tcp.Namespace != namespace {
+			return UpdateConstraintViolationError{
+				Reason:  "ImmutableFieldChanged",
+				Message: fmt.Sprintf("deploymentNamespace cannot be changed from %s to %s without the %s annotation", tcp.Namespace, namespace, kcpv1alpha2.MigrationAnnotation),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+// maxReportedDriftedFields is the number of drifted fields listed in the TenantControlPlaneInSync condition message.
+const maxReportedDriftedFields = 10
+
+// retainedFields are the TenantControlPlane fields kept from the live one when left empty by the desired one,
+// since defaulted by Kamaji: any other field differing from the desired one is drifted.
+var retainedFields = []string{
+	"spec.controlPlane.deployment.registrySettings.apiServerImage",
+	"spec.controlPlane.deployment.registrySettings.controllerManagerImage",
+	"spec.controlPlane.deployment.registrySettings.registry",
+	"spec.controlPlane.deployment.registrySettings.schedulerImage",
+	"spec.dataStore",
+	"spec.dataStoreSchema",
+	"spec.dataStoreUsername",
+	"spec.networkProfile.clusterDomain",
+	"spec.networkProfile.dnsServiceIPs",
+	"spec.networkProfile.podCidrs",
+	"spec.networkProfile.port",
+	"spec.networkProfile.serviceCidrs",
+}
+
+// retainedAnnotationsPath is the path prefix of the annotations, kept from the live TenantControlPlane
+// when not declared, since set by other actors such as kubectl.
+const retainedAnnotationsPath = "metadata.annotations"
+
+// tenantControlPlaneFields are the TenantControlPlane fields managed from the KamajiControlPlane.
+type tenantControlPlaneFields struct {
+	Metadata struct {
+		Labels      map[string]string `json:"labels,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+	Spec kamajiv1alpha1.TenantControlPlaneSpec `json:"spec"`
+}
+
+// marshalTenantControlPlaneFields returns the JSON of the TenantControlPlane managed fields,
+// except the annotation recording the last applied ones.
+func marshalTenantControlPlaneFields(tcp *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	var fields tenantControlPlaneFields
+	fields.Metadata.Labels = tcp.Labels
+	fields.Metadata.Annotations = maps.Clone(tcp.Annotations)
+	fields.Spec = tcp.Spec
+
+	delete(fields.Metadata.Annotations, kcpv1alpha2.LastAppliedTenantControlPlaneAnnotation)
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode the TenantControlPlane fields")
+	}
+
+	return data, nil
+}
+
+// mergePatchPaths returns the sorted paths of the fields changed by a JSON merge patch.
+func mergePatchPaths(patch []byte) ([]string, error) {
+	var changes map[string]interface{}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, errors.Wrap(err, "cannot decode the merge patch")
+	}
+
+	var paths []string
+
+	var walk func(prefix string, changes map[string]interface{})
+	walk = func(prefix string, changes map[string]interface{}) {
+		for key, value := range changes {
+			path := fieldPath(prefix, key)
+
+			if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+				walk(path, nested)
+
+				continue
+			}
+
+			paths = append(paths, path)
+		}
+	}
+	walk("", changes)
+
+	slices.Sort(paths)
+
+	return paths, nil
+}
+
+// fieldPath returns the path of the given field key nested in the prefix one.
+func fieldPath(prefix, key string) string {
+	path := key
+	// Keys such as the annotation ones are containing dots, and would be ambiguous.
+	if strings.Contains(key, ".") {
+		path = "[" + key + "]"
+	}
+
+	if prefix != "" && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+
+	return prefix + path
+}
+
+// unmarshalTenantControlPlaneFields sets the TenantControlPlane managed fields from their JSON.
+func unmarshalTenantControlPlaneFields(tcp *kamajiv1alpha1.TenantControlPlane, data []byte) error {
+	var fields tenantControlPlaneFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return errors.Wrap(err, "cannot decode the TenantControlPlane fields")
+	}
+
+	tcp.Labels, tcp.Annotations, tcp.Spec = fields.Metadata.Labels, fields.Metadata.Annotations, fields.Spec
+
+	return nil
+}
+
+// pruneRetainedFields removes from a drift merge patch the retained fields the desired TenantControlPlane
+// leaves empty, such as with an empty string or a null, since filled by Kamaji rather than drifted.
+func pruneRetainedFields(prefix string, patch map[string]interface{}) {
+	for key, value := range patch {
+		path := fieldPath(prefix, key)
+
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			if pruneRetainedFields(path, nested); len(nested) == 0 {
+				delete(patch, key)
+			}
+
+			continue
+		}
+
+		if !slices.Contains(retainedFields, path) && !strings.HasPrefix(path, retainedAnnotationsPath) {
+			continue
+		}
+
+		switch value := value.(type) {
+		case nil:
+		case string:
+			if value != "" {
+				continue
+			}
+		case float64:
+			if value != 0 {
+				continue
+			}
+		case []interface{}:
+			if len(value) > 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		delete(patch, key)
+	}
+}
+
+// reconcileTenantControlPlaneDrift computes the fields of the live TenantControlPlane drifted from the desired one,
+// mutating the live TenantControlPlane according to the drift policy: the changes made to the desired fields since
+// the last applied ones are always applied, whereas the drifted fields are reverted only when enforced.
+// The desired fields are recorded with an annotation, telling apart the KamajiControlPlane changes from the drifts.
+// The retained fields left empty by the desired TenantControlPlane are not drifted, since filled by Kamaji.
+//
+//nolint:cyclop
+func reconcileTenantControlPlaneDrift(live, desired *kamajiv1alpha1.TenantControlPlane, policy kcpv1alpha2.DriftPolicy) ([]string, error) {
+	desiredFields, err := marshalTenantControlPlaneFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if live.Annotations == nil {
+			live.Annotations = make(map[string]string)
+		}
+
+		live.Annotations[kcpv1alpha2.LastAppliedTenantControlPlaneAnnotation] = string(desiredFields)
+	}()
+	// Creating the TenantControlPlane, there's nothing to drift from.
+	if live.CreationTimestamp.IsZero() {
+		return nil, unmarshalTenantControlPlaneFields(live, desiredFields)
+	}
+	// Without the last applied fields, such as for a TenantControlPlane created by a previous release,
+	// the differences are considered as drifts.
+	lastApplied := []byte(live.Annotations[kcpv1alpha2.LastAppliedTenantControlPlaneAnnotation])
+	if len(lastApplied) == 0 {
+		lastApplied = desiredFields
+	}
+
+	liveFields, err := marshalTenantControlPlaneFields(live)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := jsonpatch.CreateMergePatch(lastApplied, desiredFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compute the KamajiControlPlane changes")
+	}
+
+	changedFields, err := jsonpatch.MergePatch(liveFields, changes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot apply the KamajiControlPlane changes")
+	}
+
+	drift, err := jsonpatch.CreateMergePatch(changedFields, desiredFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compute the TenantControlPlane drift")
+	}
+
+	var driftedFields map[string]interface{}
+	if err = json.Unmarshal(drift, &driftedFields); err != nil {
+		return nil, errors.Wrap(err, "cannot decode the TenantControlPlane drift")
+	}
+
+	pruneRetainedFields("", driftedFields)
+
+	if drift, err = json.Marshal(driftedFields); err != nil {
+		return nil, errors.Wrap(err, "cannot encode the TenantControlPlane drift")
+	}
+
+	drifted, err := mergePatchPaths(drift)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy != kcpv1alpha2.DriftPolicyReport && len(drifted) > 0 {
+		if changedFields, err = jsonpatch.MergePatch(changedFields, drift); err != nil {
+			return nil, errors.Wrap(err, "cannot revert the TenantControlPlane drift")
+		}
+	}
+
+	if err = unmarshalTenantControlPlaneFields(live, changedFields); err != nil {
+		return nil, err
+	}
+
+	return drifted, nil
+}
+
+// setTenantControlPlaneInSyncCondition reports the TenantControlPlane drifted fields with the TenantControlPlaneInSync condition.
+func setTenantControlPlaneInSyncCondition(kcp kcpv1alpha2.KamajiControlPlane, drifted []string, conditions *[]metav1.Condition) {
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.TenantControlPlaneInSyncConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "InSync",
+		ObservedGeneration: kcp.Generation,
+	}
+
+	if len(drifted) > 0 {
+		fields := strings.Join(drifted, ", ")
+		if len(drifted) > maxReportedDriftedFields {
+			fields = fmt.Sprintf("%s, and %d more", strings.Join(drifted[:maxReportedDriftedFields], ", "), len(drifted)-maxReportedDriftedFields)
+		}
+
+		switch kcp.Spec.DriftPolicy {
+		case kcpv1alpha2.DriftPolicyReport:
+			condition.Status, condition.Reason = metav1.ConditionFalse, "Drifted"
+			condition.Message = "fields drifted from the KamajiControlPlane: " + fields
+		default:
+			condition.Reason = "DriftReverted"
+			condition.Message = "drifted fields reverted to the KamajiControlPlane values: " + fields
+		}
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,298 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	. "github.com/onsi/gomega" //nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+func TestMergePatchPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected []string
+	}{
+		{
+			name:  "no changes",
+			patch: `{}`,
+		},
+		{
+			name:     "nested fields",
+			patch:    `{"spec":{"kubernetes":{"version":"v1.33.0"},"controlPlane":{"deployment":{"replicas":3}}}}`,
+			expected: []string{"spec.controlPlane.deployment.replicas", "spec.kubernetes.version"},
+		},
+		{
+			name:     "removed fields",
+			patch:    `{"spec":{"networkProfile":{"certSANs":null}}}`,
+			expected: []string{"spec.networkProfile.certSANs"},
+		},
+		{
+			name:     "keys with dots",
+			patch:    `{"metadata":{"annotations":{"kamaji.clastix.io/kubeconfig-secret-key":"value"}}}`,
+			expected: []string{"metadata.annotations[kamaji.clastix.io/kubeconfig-secret-key]"},
+		},
+		{
+			name:     "empty object",
+			patch:    `{"spec":{"addons":{"coreDNS":{}}}}`,
+			expected: []string{"spec.addons.coreDNS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			paths, err := mergePatchPaths([]byte(tt.patch))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(paths).To(Equal(tt.expected))
+		})
+	}
+}
+
+//nolint:funlen,maintidx
+func TestReconcileTenantControlPlaneDrift(t *testing.T) {
+	desiredTCP := func(version string, replicas int32) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		tcp.Name, tcp.Namespace = "tenant", "default"
+		tcp.Labels = map[string]string{"tenant.clastix.io": "tenant"}
+		tcp.Spec.Kubernetes.Version = version
+		tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(replicas)
+		tcp.Spec.ControlPlane.Service.ServiceType = "LoadBalancer"
+
+		return tcp
+	}
+	// liveTCP returns the TenantControlPlane as persisted from the given desired one, along with the server filled fields.
+	liveTCP := func(desired *kamajiv1alpha1.TenantControlPlane, lastApplied *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.TenantControlPlane {
+		tcp := desired.DeepCopy()
+		tcp.CreationTimestamp = metav1.Now()
+		tcp.Spec.NetworkProfile.DNSServiceIPs = []string{"10.96.0.10"}
+		tcp.Spec.ControlPlane.Deployment.RegistrySettings.Registry = "registry.k8s.io"
+		tcp.Spec.ControlPlane.Deployment.RegistrySettings.APIServerImage = "kube-apiserver"
+
+		if lastApplied != nil {
+			fields, err := marshalTenantControlPlaneFields(lastApplied)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tcp.Annotations = map[string]string{kcpv1alpha2.LastAppliedTenantControlPlaneAnnotation: string(fields)}
+		}
+
+		return tcp
+	}
+
+	tests := []struct {
+		name    string
+		live    func() *kamajiv1alpha1.TenantControlPlane
+		desired *kamajiv1alpha1.TenantControlPlane
+		policy  kcpv1alpha2.DriftPolicy
+		drifted []string
+		assert  func(g *WithT, live *kamajiv1alpha1.TenantControlPlane)
+	}{
+		{
+			name: "creation",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				tcp := &kamajiv1alpha1.TenantControlPlane{}
+				tcp.Name, tcp.Namespace = "tenant", "default"
+
+				return tcp
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Labels).To(Equal(map[string]string{"tenant.clastix.io": "tenant"}))
+				g.Expect(live.Spec).To(Equal(desiredTCP("v1.33.0", 2).Spec))
+			},
+		},
+		{
+			name: "server filled fields",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				return liveTCP(desiredTCP("v1.33.0", 2), desiredTCP("v1.33.0", 2))
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.NetworkProfile.DNSServiceIPs).To(Equal([]string{"10.96.0.10"}))
+				g.Expect(live.Spec.ControlPlane.Deployment.RegistrySettings.Registry).To(Equal("registry.k8s.io"))
+				g.Expect(live.Spec.ControlPlane.Deployment.RegistrySettings.APIServerImage).To(Equal("kube-apiserver"))
+			},
+		},
+		{
+			name: "legacy TenantControlPlane without the last applied fields",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				return liveTCP(desiredTCP("v1.33.0", 3), nil)
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			drifted: []string{"spec.controlPlane.deployment.replicas"},
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.ControlPlane.Deployment.Replicas).To(Equal(ptr.To(int32(2))))
+				g.Expect(live.Spec.NetworkProfile.DNSServiceIPs).To(Equal([]string{"10.96.0.10"}))
+			},
+		},
+		{
+			name: "KamajiControlPlane change",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				return liveTCP(desiredTCP("v1.32.0", 2), desiredTCP("v1.32.0", 2))
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyReport,
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.Kubernetes.Version).To(Equal("v1.33.0"))
+				g.Expect(live.Spec.ControlPlane.Deployment.RegistrySettings.APIServerImage).To(Equal("kube-apiserver"))
+			},
+		},
+		{
+			name: "KamajiControlPlane field removal",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				lastApplied := desiredTCP("v1.33.0", 2)
+				lastApplied.Spec.NetworkProfile.CertSANs = []string{"tenant.example.com"}
+
+				return liveTCP(lastApplied, lastApplied)
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.NetworkProfile.CertSANs).To(BeEmpty())
+			},
+		},
+		{
+			name: "manual edit enforced",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				tcp := liveTCP(desiredTCP("v1.33.0", 2), desiredTCP("v1.33.0", 2))
+				tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(5))
+				tcp.Labels["tenant.clastix.io"] = "edited"
+
+				return tcp
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			drifted: []string{"metadata.labels[tenant.clastix.io]", "spec.controlPlane.deployment.replicas"},
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.ControlPlane.Deployment.Replicas).To(Equal(ptr.To(int32(2))))
+				g.Expect(live.Labels).To(Equal(map[string]string{"tenant.clastix.io": "tenant"}))
+				g.Expect(live.Spec.NetworkProfile.DNSServiceIPs).To(Equal([]string{"10.96.0.10"}))
+			},
+		},
+		{
+			name: "operator added label",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				tcp := liveTCP(desiredTCP("v1.33.0", 2), desiredTCP("v1.33.0", 2))
+				tcp.Labels["debug"] = "true"
+
+				return tcp
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			drifted: []string{"metadata.labels.debug"},
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Labels).To(Equal(map[string]string{"tenant.clastix.io": "tenant"}))
+			},
+		},
+		{
+			name: "operator added spec field",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				tcp := liveTCP(desiredTCP("v1.33.0", 2), desiredTCP("v1.33.0", 2))
+				tcp.Spec.ControlPlane.Deployment.NodeSelector = map[string]string{"kubernetes.io/hostname": "debug"}
+
+				return tcp
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			drifted: []string{"spec.controlPlane.deployment.nodeSelector"},
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.ControlPlane.Deployment.NodeSelector).To(BeEmpty())
+				g.Expect(live.Spec.ControlPlane.Deployment.RegistrySettings.APIServerImage).To(Equal("kube-apiserver"))
+			},
+		},
+		{
+			name: "explicit false flipped to true",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				desired := desiredTCP("v1.33.0", 2)
+				desired.Spec.ControlPlane.Deployment.AdditionalContainers = []corev1.Container{{Name: "sidecar"}}
+
+				tcp := liveTCP(desired, desired)
+				tcp.Spec.ControlPlane.Deployment.AdditionalContainers[0].Stdin = true
+
+				return tcp
+			},
+			desired: func() *kamajiv1alpha1.TenantControlPlane {
+				desired := desiredTCP("v1.33.0", 2)
+				desired.Spec.ControlPlane.Deployment.AdditionalContainers = []corev1.Container{{Name: "sidecar"}}
+
+				return desired
+			}(),
+			policy:  kcpv1alpha2.DriftPolicyReport,
+			drifted: []string{"spec.controlPlane.deployment.additionalContainers"},
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.ControlPlane.Deployment.AdditionalContainers[0].Stdin).To(BeTrue())
+			},
+		},
+		{
+			name: "annotation set by another actor",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				tcp := liveTCP(desiredTCP("v1.33.0", 2), desiredTCP("v1.33.0", 2))
+				tcp.Annotations["kubectl.kubernetes.io/restartedAt"] = "2026-01-01T00:00:00Z"
+
+				return tcp
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyEnforce,
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Annotations).To(HaveKeyWithValue("kubectl.kubernetes.io/restartedAt", "2026-01-01T00:00:00Z"))
+			},
+		},
+		{
+			name: "manual edit reported",
+			live: func() *kamajiv1alpha1.TenantControlPlane {
+				tcp := liveTCP(desiredTCP("v1.32.0", 2), desiredTCP("v1.32.0", 2))
+				tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(5))
+
+				return tcp
+			},
+			desired: desiredTCP("v1.33.0", 2),
+			policy:  kcpv1alpha2.DriftPolicyReport,
+			drifted: []string{"spec.controlPlane.deployment.replicas"},
+			assert: func(g *WithT, live *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(live.Spec.ControlPlane.Deployment.Replicas).To(Equal(ptr.To(int32(5))))
+				g.Expect(live.Spec.Kubernetes.Version).To(Equal("v1.33.0"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			live := tt.live()
+
+			drifted, err := reconcileTenantControlPlaneDrift(live, tt.desired, tt.policy)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drifted).To(Equal(tt.drifted))
+
+			tt.assert(g, live)
+
+			desiredFields, err := marshalTenantControlPlaneFields(tt.desired)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(live.Annotations).To(HaveKeyWithValue(kcpv1alpha2.LastAppliedTenantControlPlaneAnnotation, string(desiredFields)))
+			// Reconciling again, the TenantControlPlane has no more drifts unless reported.
+			drifted, err = reconcileTenantControlPlaneDrift(live, tt.desired, tt.policy)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tt.policy == kcpv1alpha2.DriftPolicyReport {
+				g.Expect(drifted).To(Equal(tt.drifted))
+			} else {
+				g.Expect(drifted).To(BeEmpty())
+			}
+		})
+	}
+}
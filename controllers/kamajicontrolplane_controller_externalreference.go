@@ -14,6 +14,7 @@ import (
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
 	ecr "github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/externalclusterreference"
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/features"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/tracing"
 )
 
 const (
@@ -73,5 +74,5 @@ func (r *KamajiControlPlaneReconciler) extractRemoteClient(ctx context.Context,
 		return nil, ErrExternalClusterReferenceTenantControlPlaneNotFound
 	}
 
-	return mgr.GetClient(), nil
+	return tracing.NewClient(mgr.GetClient(), "RemoteClient"), nil
 }
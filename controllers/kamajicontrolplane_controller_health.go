@@ -0,0 +1,238 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/metrics"
+)
+
+const (
+	// healthProbePeriod is the period the Tenant Control Plane API server is probed, regardless of the watched events.
+	healthProbePeriod = time.Minute
+	// healthProbeTimeout is the maximum time the Tenant Control Plane API server is waited for answering a probe.
+	healthProbeTimeout = 10 * time.Second
+)
+
+// healthProbeEndpoints are the API server paths probed with the verbose output, listing the single checks.
+var healthProbeEndpoints = []string{"/readyz", "/livez"}
+
+// parseHealthChecks extracts the single checks from the verbose output of the API server health endpoints,
+// such as "[+]ping ok" or "[-]etcd failed: reason withheld".
+func parseHealthChecks(body []byte) []kcpv1alpha2.KamajiControlPlaneHealthCheckStatus {
+	var checks []kcpv1alpha2.KamajiControlPlaneHealthCheckStatus
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var healthy bool
+
+		switch {
+		case strings.HasPrefix(line, "[+]"):
+			healthy = true
+		case strings.HasPrefix(line, "[-]"):
+			healthy = false
+		default:
+			continue
+		}
+
+		name, _, _ := strings.Cut(line[3:], " ")
+
+		checks = append(checks, kcpv1alpha2.KamajiControlPlaneHealthCheckStatus{Name: name, Healthy: healthy})
+	}
+
+	return checks
+}
+
+// healthProbeSchedule tracks the time each Tenant Control Plane API server has been last probed at: it's kept in memory
+// rather than in the status, which is updated only when the outcome of the probes changes.
+type healthProbeSchedule struct {
+	clock clock.PassiveClock
+
+	lock sync.Mutex
+	// probed is the time the API server of each KamajiControlPlane has been last probed at.
+	probed map[types.NamespacedName]time.Time
+}
+
+func newHealthProbeSchedule(clock clock.PassiveClock) *healthProbeSchedule {
+	return &healthProbeSchedule{
+		clock:  clock,
+		probed: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// next returns true when the API server is due to be probed, recording the probe, along with the delay before the next one.
+func (s *healthProbeSchedule) next(key types.NamespacedName) (bool, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Now()
+
+	if probed, ok := s.probed[key]; ok && now.Sub(probed) < healthProbePeriod {
+		return false, probed.Add(healthProbePeriod).Sub(now)
+	}
+
+	s.probed[key] = now
+
+	return true, healthProbePeriod
+}
+
+// forget must be called once the KamajiControlPlane has been deleted.
+func (s *healthProbeSchedule) forget(key types.NamespacedName) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.probed, key)
+}
+
+// probeControlPlaneHealth probes the Tenant Control Plane API server health endpoints through the shared workload
+// cluster connection, collecting the per-check results: the latency of each endpoint is observed with a metric.
+func (r *KamajiControlPlaneReconciler) probeControlPlaneHealth(ctx context.Context, cluster capiv1beta2.Cluster) ([]kcpv1alpha2.KamajiControlPlaneHealthProbeStatus, error) {
+	sharedConfig, err := r.ClusterCache.GetRESTConfig(ctx, client.ObjectKeyFromObject(&cluster))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve workload cluster REST config")
+	}
+	// The REST config is shared by the workload cluster clients, it must not be mutated.
+	config := rest.CopyConfig(sharedConfig)
+	config.Timeout = healthProbeTimeout
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create workload cluster client-set")
+	}
+
+	probes := make([]kcpv1alpha2.KamajiControlPlaneHealthProbeStatus, 0, len(healthProbeEndpoints))
+
+	for _, endpoint := range healthProbeEndpoints {
+		start := time.Now()
+		body, probeErr := clientset.Discovery().RESTClient().Get().AbsPath(endpoint).Param("verbose", "true").DoRaw(ctx)
+
+		result := "healthy"
+		if probeErr != nil {
+			result = "unhealthy"
+		}
+
+		metrics.ControlPlaneHealthProbeDuration.WithLabelValues(endpoint, result).Observe(time.Since(start).Seconds())
+
+		probe := kcpv1alpha2.KamajiControlPlaneHealthProbeStatus{
+			Endpoint: endpoint,
+			Healthy:  probeErr == nil,
+			Checks:   parseHealthChecks(body),
+		}
+
+		if probeErr != nil {
+			var failedChecks []string
+
+			for _, check := range probe.Checks {
+				if !check.Healthy {
+					failedChecks = append(failedChecks, check.Name)
+				}
+			}
+
+			probe.Message = probeErr.Error()
+			if len(failedChecks) > 0 {
+				probe.Message = "failed checks " + strings.Join(failedChecks, ", ")
+			}
+		}
+
+		probes = append(probes, probe)
+	}
+
+	return probes, nil
+}
+
+// transitionHealthStatus returns the health status reporting the given probes: the current one is kept as it is
+// when the outcome of the probes didn't change, sparing the status update.
+func transitionHealthStatus(current *kcpv1alpha2.KamajiControlPlaneHealthStatus, probes []kcpv1alpha2.KamajiControlPlaneHealthProbeStatus, now time.Time) *kcpv1alpha2.KamajiControlPlaneHealthStatus {
+	if current != nil && equality.Semantic.DeepEqual(current.Probes, probes) {
+		return current
+	}
+
+	return &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: metav1.NewTime(now), Probes: probes}
+}
+
+// reconcileControlPlaneHealth reports the Tenant Control Plane API server health with the ControlPlaneHealthy condition.
+// The API server is probed at most once per period, since the status update triggers a further reconciliation:
+// it returns false when the API server has been reported as unhealthy, along with the delay before the next probe.
+func (r *KamajiControlPlaneReconciler) reconcileControlPlaneHealth(ctx context.Context, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane, health **kcpv1alpha2.KamajiControlPlaneHealthStatus, conditions *[]metav1.Condition) (bool, time.Duration) {
+	log := ctrllog.FromContext(ctx)
+
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.ControlPlaneHealthyConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "ControlPlaneHealthy",
+		ObservedGeneration: kcp.Generation,
+	}
+
+	due, requeueAfter := r.healthProbeSchedule.next(client.ObjectKeyFromObject(&kcp))
+
+	if due {
+		probes, err := r.probeControlPlaneHealth(ctx, cluster)
+		if err != nil {
+			// The API server cannot be probed, the health is unknown: the availability is not affected,
+			// since this doesn't tell anything about the Tenant Control Plane.
+			log.Error(err, "unable to probe the Tenant Control Plane API server")
+
+			*health = nil
+
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionUnknown, "ControlPlaneHealthUnknown", err.Error()
+			meta.SetStatusCondition(conditions, condition)
+
+			return true, requeueAfter
+		}
+
+		*health = transitionHealthStatus(*health, probes, time.Now())
+	}
+
+	status := *health
+	// The latest probe failed, and the next one is not yet due: keeping the unknown health.
+	if status == nil {
+		return true, requeueAfter
+	}
+
+	var results, failures []string
+
+	for _, probe := range status.Probes {
+		if probe.Healthy {
+			results = append(results, probe.Endpoint+" passed")
+
+			continue
+		}
+
+		failures = append(failures, fmt.Sprintf("%s failed (%s)", probe.Endpoint, probe.Message))
+	}
+
+	condition.Message = strings.Join(results, ", ")
+
+	if len(failures) > 0 {
+		log.Info("Tenant Control Plane API server is not healthy", "failures", failures)
+
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "ControlPlaneUnhealthy", strings.Join(failures, ", ")
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+
+	return len(failures) == 0, requeueAfter
+}
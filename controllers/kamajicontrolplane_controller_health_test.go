@@ -0,0 +1,99 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+func TestHealthProbeSchedule(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	schedule := newHealthProbeSchedule(fakeClock)
+	key := types.NamespacedName{Namespace: "default", Name: "tenant"}
+
+	due, requeueAfter := schedule.next(key)
+	g.Expect(due).To(BeTrue())
+	g.Expect(requeueAfter).To(Equal(healthProbePeriod))
+	// Triggered by a watch before the next probe is due.
+	fakeClock.SetTime(fakeClock.Now().Add(20 * time.Second))
+	due, requeueAfter = schedule.next(key)
+	g.Expect(due).To(BeFalse())
+	g.Expect(requeueAfter).To(Equal(40 * time.Second))
+	// Triggered by the requeue.
+	fakeClock.SetTime(fakeClock.Now().Add(40 * time.Second))
+	due, requeueAfter = schedule.next(key)
+	g.Expect(due).To(BeTrue())
+	g.Expect(requeueAfter).To(Equal(healthProbePeriod))
+	// The KamajiControlPlane has been deleted, and created again.
+	schedule.forget(key)
+	due, _ = schedule.next(key)
+	g.Expect(due).To(BeTrue())
+}
+
+func TestTransitionHealthStatus(t *testing.T) {
+	lastTransitionTime, now := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second)), time.Now().Truncate(time.Second)
+
+	probes := func(healthy bool) []kcpv1alpha2.KamajiControlPlaneHealthProbeStatus {
+		probe := kcpv1alpha2.KamajiControlPlaneHealthProbeStatus{
+			Endpoint: "/readyz",
+			Healthy:  healthy,
+			Checks:   []kcpv1alpha2.KamajiControlPlaneHealthCheckStatus{{Name: "ping", Healthy: true}, {Name: "etcd", Healthy: healthy}},
+		}
+
+		if !healthy {
+			probe.Message = "failed checks etcd"
+		}
+
+		return []kcpv1alpha2.KamajiControlPlaneHealthProbeStatus{probe}
+	}
+
+	tests := []struct {
+		name     string
+		current  *kcpv1alpha2.KamajiControlPlaneHealthStatus
+		probes   []kcpv1alpha2.KamajiControlPlaneHealthProbeStatus
+		expected *kcpv1alpha2.KamajiControlPlaneHealthStatus
+	}{
+		{
+			name:     "first probe",
+			probes:   probes(true),
+			expected: &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: metav1.NewTime(now), Probes: probes(true)},
+		},
+		{
+			name:     "unchanged",
+			current:  &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: lastTransitionTime, Probes: probes(true)},
+			probes:   probes(true),
+			expected: &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: lastTransitionTime, Probes: probes(true)},
+		},
+		{
+			name:     "became unhealthy",
+			current:  &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: lastTransitionTime, Probes: probes(true)},
+			probes:   probes(false),
+			expected: &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: metav1.NewTime(now), Probes: probes(false)},
+		},
+		{
+			name:     "still unhealthy",
+			current:  &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: lastTransitionTime, Probes: probes(false)},
+			probes:   probes(false),
+			expected: &kcpv1alpha2.KamajiControlPlaneHealthStatus{LastTransitionTime: lastTransitionTime, Probes: probes(false)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(transitionHealthStatus(tt.current, tt.probes, now)).To(Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/retry"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const (
+	// issuedKubeconfigLabel reports the name of the kubeconfig spec an issued kubeconfig Secret has been generated for.
+	issuedKubeconfigLabel = "kamaji.controlplane.cluster.x-k8s.io/kubeconfig"
+	// issuedKubeconfigChecksumAnnotation stores the checksum of the inputs an issued kubeconfig has been generated from,
+	// such as the user, the groups, the endpoint, and the Certificate Authority: a change triggers a new issue.
+	issuedKubeconfigChecksumAnnotation = "kamaji.controlplane.cluster.x-k8s.io/kubeconfig-checksum"
+)
+
+var (
+	ErrMissingPrivateKey     = errors.New("no PEM encoded private key found")
+	ErrUnsupportedPrivateKey = errors.New("unsupported private key type")
+)
+
+// issuedKubeconfigSecretName returns the name of the Secret storing an issued kubeconfig.
+func issuedKubeconfigSecretName(cluster capiv1beta2.Cluster, spec kcpv1alpha2.KubeconfigSpec) string {
+	return cluster.Name + "-kubeconfig-" + spec.Name
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse private key")
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedPrivateKey
+	}
+
+	return signer, nil
+}
+
+// kubeconfigCluster returns the cluster entry of the Kamaji admin kubeconfig matching the given endpoint.
+func kubeconfigCluster(secret *corev1.Secret, endpoint kcpv1alpha2.KubeconfigEndpoint) (*clientcmdapi.Cluster, error) {
+	key := "admin.conf"
+	if endpoint == kcpv1alpha2.KubeconfigEndpointService {
+		key = "admin.svc"
+	}
+
+	config, err := clientcmd.Load(secret.Data[key])
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load admin kubeconfig from key %s", key)
+	}
+
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, errors.Errorf("missing current context %q in admin kubeconfig", config.CurrentContext)
+	}
+
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return nil, errors.Errorf("missing cluster %q in admin kubeconfig", kubeContext.Cluster)
+	}
+
+	return cluster, nil
+}
+
+// issueKubeconfig generates a kubeconfig with a client certificate signed by the tenant Certificate Authority.
+func issueKubeconfig(clusterName string, cluster *clientcmdapi.Cluster, spec kcpv1alpha2.KubeconfigSpec, caCrt *x509.Certificate, caKey crypto.Signer) ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate private key")
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128)) //nolint:mnd
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate serial number")
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   spec.User,
+			Organization: spec.Groups,
+		},
+		NotBefore:   now,
+		NotAfter:    now.Add(spec.TTL.Duration),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	crt, err := x509.CreateCertificate(rand.Reader, template, caCrt, key.Public(), caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot sign client certificate")
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal private key")
+	}
+
+	contextName := spec.User + "@" + clusterName
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[clusterName] = &clientcmdapi.Cluster{
+		Server:                   cluster.Server,
+		CertificateAuthorityData: cluster.CertificateAuthorityData,
+	}
+	config.AuthInfos[spec.User] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crt}),
+		ClientKeyData:         pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}),
+	}
+	config.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:  clusterName,
+		AuthInfo: spec.User,
+	}
+	config.CurrentContext = contextName
+
+	value, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot serialize kubeconfig")
+	}
+
+	return value, nil
+}
+
+// reconcileKubeconfigs issues the kubeconfigs declared in the KamajiControlPlane spec, renewing them once two thirds
+// of their validity have elapsed, and deletes the ones no longer declared.
+// It returns the delay before the next renewal, if any.
+//
+//nolint:funlen,cyclop
+func (r *KamajiControlPlaneReconciler) reconcileKubeconfigs(ctx context.Context, remoteClient client.Client, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) (time.Duration, error) {
+	log := ctrllog.FromContext(ctx)
+
+	var secretList corev1.SecretList
+	if err := r.client.List(ctx, &secretList, client.InNamespace(cluster.Namespace), client.HasLabels{issuedKubeconfigLabel}, client.MatchingLabels{capiv1beta2.ClusterNameLabel: cluster.Name}); err != nil {
+		return 0, errors.Wrap(err, "cannot list issued kubeconfig Secrets")
+	}
+
+	declared := make(map[string]struct{}, len(kcp.Spec.Kubeconfigs))
+	for _, spec := range kcp.Spec.Kubeconfigs {
+		declared[issuedKubeconfigSecretName(cluster, spec)] = struct{}{}
+	}
+
+	for _, secret := range secretList.Items {
+		if _, ok := declared[secret.Name]; ok || !metav1.IsControlledBy(&secret, &kcp) {
+			continue
+		}
+
+		if err := r.client.Delete(ctx, &secret); client.IgnoreNotFound(err) != nil {
+			return 0, errors.Wrapf(err, "cannot delete issued kubeconfig Secret %s", secret.Name)
+		}
+
+		log.Info("issued kubeconfig no longer declared, deleted", "secret", secret.Name)
+	}
+
+	if len(kcp.Spec.Kubeconfigs) == 0 {
+		return 0, nil
+	}
+
+	reader := r.client
+
+	if remoteClient != nil {
+		reader = remoteClient
+	}
+
+	kamajiCA, kamajiAdminKubeconfig := &corev1.Secret{}, &corev1.Secret{}
+
+	if err := reader.Get(ctx, types.NamespacedName{Name: tcp.Status.Certificates.CA.SecretName, Namespace: tcp.Namespace}, kamajiCA); err != nil {
+		return 0, errors.Wrap(err, "cannot retrieve source-of-truth as Certificate Authority")
+	}
+
+	if err := reader.Get(ctx, types.NamespacedName{Name: tcp.Status.KubeConfig.Admin.SecretName, Namespace: tcp.Namespace}, kamajiAdminKubeconfig); err != nil {
+		return 0, errors.Wrap(err, "cannot retrieve source-of-truth for admin kubeconfig")
+	}
+
+	caCrt, err := parseCertificate(kamajiCA.Data["ca.crt"])
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot parse Certificate Authority certificate")
+	}
+
+	caKey, err := parsePrivateKey(kamajiCA.Data["ca.key"])
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot parse Certificate Authority private key")
+	}
+
+	var requeueAfter time.Duration
+
+	for _, spec := range kcp.Spec.Kubeconfigs {
+		clusterEntry, clusterErr := kubeconfigCluster(kamajiAdminKubeconfig, spec.Endpoint)
+		if clusterErr != nil {
+			return 0, clusterErr
+		}
+
+		if spec.Server != "" {
+			clusterEntry = clusterEntry.DeepCopy()
+			clusterEntry.Server = spec.Server
+		}
+
+		checksum := secretChecksum([]byte(spec.User), []byte(strings.Join(spec.Groups, ",")), []byte(spec.TTL.Duration.String()), []byte(clusterEntry.Server), clusterEntry.CertificateAuthorityData, kamajiCA.Data["ca.crt"])
+
+		secret := &corev1.Secret{}
+		secret.Name = issuedKubeconfigSecretName(cluster, spec)
+		secret.Namespace = cluster.Namespace
+
+		var renewAt time.Time
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			_, scopeErr := controllerutil.CreateOrUpdate(ctx, r.client, secret, func() error {
+				labels := secret.GetLabels()
+				if labels == nil {
+					labels = map[string]string{}
+				}
+
+				labels[capiv1beta2.ClusterNameLabel] = cluster.Name
+				labels["kamaji.clastix.io/component"] = "capi"
+				labels["kamaji.clastix.io/secret"] = "issued-kubeconfig"
+				labels["kamaji.clastix.io/cluster"] = cluster.Name
+				labels["kamaji.clastix.io/tcp"] = tcp.Name
+				labels[issuedKubeconfigLabel] = spec.Name
+
+				secret.SetLabels(labels)
+
+				annotations := secret.GetAnnotations()
+				if annotations == nil {
+					annotations = map[string]string{}
+				}
+				// Keeping the issued kubeconfig, unless the inputs changed, or it's due for renewal.
+				if crt, crtErr := parseKubeconfigCertificate("value")(secret); crtErr == nil && annotations[issuedKubeconfigChecksumAnnotation] == checksum {
+					if renewAt = crt.NotBefore.Add(crt.NotAfter.Sub(crt.NotBefore) * 2 / 3); time.Now().Before(renewAt) {
+						return controllerutil.SetControllerReference(&kcp, secret, r.client.Scheme())
+					}
+				}
+
+				value, issueErr := issueKubeconfig(cluster.Name, clusterEntry, spec, caCrt, caKey)
+				if issueErr != nil {
+					return issueErr
+				}
+
+				renewAt = time.Now().Add(spec.TTL.Duration * 2 / 3)
+
+				annotations[issuedKubeconfigChecksumAnnotation] = checksum
+				secret.SetAnnotations(annotations)
+
+				secret.Data = map[string][]byte{
+					"value": value,
+				}
+				secret.Type = capiv1beta2.ClusterSecretType
+
+				log.Info("kubeconfig issued", "secret", secret.Name, "user", spec.User)
+
+				return controllerutil.SetControllerReference(&kcp, secret, r.client.Scheme())
+			})
+
+			return scopeErr //nolint:wrapcheck
+		})
+		if err != nil {
+			return 0, errors.Wrapf(err, "cannot create or update issued kubeconfig Secret %s", secret.Name)
+		}
+
+		if untilRenewal := max(time.Until(renewAt), time.Second); requeueAfter == 0 || untilRenewal < requeueAfter {
+			requeueAfter = untilRenewal
+		}
+	}
+
+	return requeueAfter, nil
+}
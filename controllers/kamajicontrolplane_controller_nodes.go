@@ -0,0 +1,153 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const (
+	// nodeVersionsPeriod is the period the workload cluster Nodes are listed, regardless of the watched events.
+	nodeVersionsPeriod = time.Minute
+	// defaultMaxMinorVersionSkew is the number of minor versions a kubelet can be older than the control plane,
+	// according to the Kubernetes version skew policy.
+	defaultMaxMinorVersionSkew int32 = 3
+)
+
+// maxMinorVersionSkew returns the number of minor versions a kubelet is allowed to be older than the control plane.
+func maxMinorVersionSkew(kcp kcpv1alpha2.KamajiControlPlane) int32 {
+	if kcp.Spec.NodeVersionSkewPolicy == nil || kcp.Spec.NodeVersionSkewPolicy.MaxMinorVersionSkew == nil {
+		return defaultMaxMinorVersionSkew
+	}
+
+	return *kcp.Spec.NodeVersionSkewPolicy.MaxMinorVersionSkew
+}
+
+// workloadClusterNodes lists the workload cluster Nodes through the shared workload cluster client.
+func (r *KamajiControlPlaneReconciler) workloadClusterNodes(ctx context.Context, cluster capiv1beta2.Cluster) ([]corev1.Node, error) {
+	workloadClient, err := r.ClusterCache.GetClient(ctx, client.ObjectKeyFromObject(&cluster))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve workload cluster client")
+	}
+
+	var nodeList corev1.NodeList
+	if err = workloadClient.List(ctx, &nodeList); err != nil {
+		return nil, errors.Wrap(err, "cannot list workload cluster Nodes")
+	}
+
+	return nodeList.Items, nil
+}
+
+// reconcileNodeVersions summarises the kubelet versions of the workload cluster Nodes, reporting with the
+// NodeVersionSkewSatisfied condition whether they're supported by the desired control plane version.
+// The Nodes are listed at most once per period, since the status update triggers a further reconciliation:
+// it returns the delay before the next listing. The last known summary is retained when the Nodes cannot be listed.
+func (r *KamajiControlPlaneReconciler) reconcileNodeVersions(ctx context.Context, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane, nodeVersions **kcpv1alpha2.KamajiControlPlaneNodeVersionsStatus, conditions *[]metav1.Condition) time.Duration {
+	log := ctrllog.FromContext(ctx)
+
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.NodeVersionSkewSatisfiedConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "Satisfied",
+		ObservedGeneration: kcp.Generation,
+	}
+
+	status := (*nodeVersions).DeepCopy()
+
+	if status == nil || time.Since(status.LastProbeTime.Time) >= nodeVersionsPeriod {
+		nodes, err := r.workloadClusterNodes(ctx, cluster)
+		if err != nil {
+			log.Error(err, "unable to summarise the workload cluster Node versions")
+
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionUnknown, "NodeVersionsUnknown", err.Error()
+			meta.SetStatusCondition(conditions, condition)
+
+			return nodeVersionsPeriod
+		}
+
+		status = &kcpv1alpha2.KamajiControlPlaneNodeVersionsStatus{LastProbeTime: metav1.Now(), Nodes: int32(len(nodes))} //nolint:gosec
+
+		for _, node := range nodes {
+			kubeletVersion := node.Status.NodeInfo.KubeletVersion
+
+			index := slices.IndexFunc(status.KubeletVersions, func(v kcpv1alpha2.KamajiControlPlaneKubeletVersionStatus) bool {
+				return v.Version == kubeletVersion
+			})
+			if index < 0 {
+				status.KubeletVersions = append(status.KubeletVersions, kcpv1alpha2.KamajiControlPlaneKubeletVersionStatus{Version: kubeletVersion})
+				index = len(status.KubeletVersions) - 1
+			}
+
+			status.KubeletVersions[index].Nodes++
+		}
+		// Sorting the versions to avoid status updates due to the Nodes listing order.
+		slices.SortFunc(status.KubeletVersions, func(a, b kcpv1alpha2.KamajiControlPlaneKubeletVersionStatus) int {
+			return strings.Compare(a.Version, b.Version)
+		})
+	}
+	// The supported versions are computed on each reconciliation, since the desired control plane version
+	// or the skew policy could have been changed since the Nodes have been listed.
+	controlPlaneVersion := normalizeVersion(kcp.Spec.Version)
+
+	var violations []string
+
+	for i, kubeletVersion := range status.KubeletVersions {
+		skewErr := kcpv1alpha2.ValidateKubeletVersionSkew(controlPlaneVersion, kubeletVersion.Version, maxMinorVersionSkew(kcp))
+		if skewErr != nil {
+			violations = append(violations, skewErr.Error())
+		}
+
+		status.KubeletVersions[i].Supported = skewErr == nil
+	}
+
+	*nodeVersions = status
+
+	if len(violations) > 0 {
+		condition.Status, condition.Reason = metav1.ConditionFalse, "NodeVersionSkewViolated"
+		condition.Message = fmt.Sprintf("kubelet versions outside the supported skew with %s: %s", controlPlaneVersion, strings.Join(violations, ", "))
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+
+	return time.Until(status.LastProbeTime.Add(nodeVersionsPeriod))
+}
+
+// checkNodeVersionSkew refuses a control plane upgrade leaving existing Nodes outside the supported skew,
+// when requested with the NodeVersionSkewPolicy.
+func checkNodeVersionSkew(kcp kcpv1alpha2.KamajiControlPlane, nodeVersions *kcpv1alpha2.KamajiControlPlaneNodeVersionsStatus) error {
+	if kcp.Spec.NodeVersionSkewPolicy == nil || !kcp.Spec.NodeVersionSkewPolicy.BlockUpgrades || nodeVersions == nil {
+		return nil
+	}
+
+	var unsupported []string
+	// Not relying on the reported supported versions, which could be retained from a previous control plane version.
+	for _, kubeletVersion := range nodeVersions.KubeletVersions {
+		if kcpv1alpha2.ValidateKubeletVersionSkew(normalizeVersion(kcp.Spec.Version), kubeletVersion.Version, maxMinorVersionSkew(kcp)) != nil {
+			unsupported = append(unsupported, fmt.Sprintf("%s (%d nodes)", kubeletVersion.Version, kubeletVersion.Nodes))
+		}
+	}
+
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	return UpdateConstraintViolationError{
+		Reason:  "NodeVersionSkew",
+		Message: fmt.Sprintf("upgrading to %s would leave Nodes outside the supported kubelet versions skew: %s", normalizeVersion(kcp.Spec.Version), strings.Join(unsupported, ", ")),
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const (
+	// resourceUsageSamplePeriod is the period the usage of the TenantControlPlane pods is sampled.
+	resourceUsageSamplePeriod         = time.Minute
+	defaultResourceObservationWindow  = 24 * time.Hour
+	defaultResourceHeadroomPercentage = 20
+)
+
+//+kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// controlPlaneComponentContainers maps the control plane components to the containers of the TenantControlPlane pods.
+var controlPlaneComponentContainers = []struct {
+	component string
+	container string
+}{
+	{component: "apiServer", container: "kube-apiserver"},
+	{component: "controllerManager", container: "kube-controller-manager"},
+	{component: "scheduler", container: "kube-scheduler"},
+	{component: "kine", container: "kine"},
+}
+
+// podMetrics is the subset of the metrics.k8s.io PodMetrics used to compute the recommendations.
+type podMetrics struct {
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// tenantControlPlaneUsage returns the highest usage of a single TenantControlPlane pod, per control plane component.
+// The metrics are read with a non-caching reader since the metrics.k8s.io API doesn't support watches:
+// the remote client is not caching unstructured objects.
+func (r *KamajiControlPlaneReconciler) tenantControlPlaneUsage(ctx context.Context, remoteClient client.Client, tcp *kamajiv1alpha1.TenantControlPlane) (map[string]corev1.ResourceList, error) {
+	reader := r.apiReader
+	if remoteClient != nil {
+		reader = remoteClient
+	}
+
+	if tcp.Status.Kubernetes.Deployment.Selector == "" {
+		return nil, errors.New("the TenantControlPlane pods selector is not yet reported")
+	}
+
+	selector, err := labels.Parse(tcp.Status.Kubernetes.Deployment.Selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse the TenantControlPlane pods selector")
+	}
+
+	podMetricsList := &unstructured.UnstructuredList{}
+	podMetricsList.SetGroupVersionKind(schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"})
+
+	if err = reader.List(ctx, podMetricsList, client.InNamespace(tcp.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrap(err, "cannot list the TenantControlPlane pods metrics")
+	}
+
+	usage := map[string]corev1.ResourceList{}
+
+	for _, item := range podMetricsList.Items {
+		var metrics podMetrics
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &metrics); err != nil {
+			return nil, errors.Wrapf(err, "cannot decode the metrics of pod %s", item.GetName())
+		}
+
+		for _, container := range metrics.Containers {
+			index := slices.IndexFunc(controlPlaneComponentContainers, func(c struct{ component, container string }) bool {
+				return c.container == container.Name
+			})
+			if index < 0 {
+				continue
+			}
+
+			component := controlPlaneComponentContainers[index].component
+			usage[component] = maxResourceList(usage[component], container.Usage)
+		}
+	}
+
+	return usage, nil
+}
+
+// maxResourceList returns the highest quantity of each resource of the two lists.
+func maxResourceList(a, b corev1.ResourceList) corev1.ResourceList {
+	out := a.DeepCopy()
+	if out == nil {
+		out = corev1.ResourceList{}
+	}
+
+	for name, quantity := range b {
+		if current, ok := out[name]; !ok || quantity.Cmp(current) > 0 {
+			out[name] = quantity.DeepCopy()
+		}
+	}
+
+	return out
+}
+
+// recommendRequests computes the requests from the peak usage with the configured headroom,
+// bounded by the allowed values and the limits declared for the component.
+func recommendRequests(peakUsage corev1.ResourceList, recommendations kcpv1alpha2.ResourceRecommendationsSpec, limits corev1.ResourceList) corev1.ResourceList {
+	headroom := int64(defaultResourceHeadroomPercentage)
+	if recommendations.HeadroomPercentage > 0 {
+		headroom = int64(recommendations.HeadroomPercentage)
+	}
+
+	requests := corev1.ResourceList{}
+
+	for name, usage := range peakUsage {
+		var recommended resource.Quantity
+
+		switch name {
+		case corev1.ResourceCPU:
+			recommended = *resource.NewMilliQuantity(usage.MilliValue()*(100+headroom)/100, resource.DecimalSI)
+		case corev1.ResourceMemory:
+			recommended = *resource.NewQuantity(usage.Value()*(100+headroom)/100, resource.BinarySI)
+		default:
+			continue
+		}
+
+		if minAllowed, ok := recommendations.MinAllowed[name]; ok && recommended.Cmp(minAllowed) < 0 {
+			recommended = minAllowed.DeepCopy()
+		}
+
+		if maxAllowed, ok := recommendations.MaxAllowed[name]; ok && recommended.Cmp(maxAllowed) > 0 {
+			recommended = maxAllowed.DeepCopy()
+		}
+
+		if limit, ok := limits[name]; ok && recommended.Cmp(limit) > 0 {
+			recommended = limit.DeepCopy()
+		}
+
+		requests[name] = recommended
+	}
+
+	return requests
+}
+
+// componentResources returns the declared resources of a control plane component: with the Auto mode,
+// the requests applied from the recommendations take precedence, bounded by the declared limits.
+func componentResources(kcp kcpv1alpha2.KamajiControlPlane, component string, declared corev1.ResourceRequirements) *corev1.ResourceRequirements {
+	resources := declared.DeepCopy()
+
+	if kcp.Spec.ResourceRecommendations == nil || kcp.Spec.ResourceRecommendations.Mode != kcpv1alpha2.ResourceRecommendationsModeAuto || kcp.Status.ResourceRecommendations == nil {
+		return resources
+	}
+
+	index := slices.IndexFunc(kcp.Status.ResourceRecommendations.Components, func(status kcpv1alpha2.KamajiControlPlaneComponentResourcesStatus) bool {
+		return status.Name == component
+	})
+	if index < 0 {
+		return resources
+	}
+
+	for name, applied := range kcp.Status.ResourceRecommendations.Components[index].Applied {
+		if limit, ok := resources.Limits[name]; ok && applied.Cmp(limit) > 0 {
+			applied = limit.DeepCopy()
+		}
+
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+
+		resources.Requests[name] = applied
+	}
+
+	return resources
+}
+
+// componentLimits returns the limits declared for a control plane component.
+func componentLimits(kcp kcpv1alpha2.KamajiControlPlane, component string) corev1.ResourceList {
+	switch component {
+	case "apiServer":
+		return kcp.Spec.ApiServer.Resources.Limits
+	case "controllerManager":
+		return kcp.Spec.ControllerManager.Resources.Limits
+	case "scheduler":
+		return kcp.Spec.Scheduler.Resources.Limits
+	case "kine":
+		return kcp.Spec.Kine.Resources.Limits
+	default:
+		return nil
+	}
+}
+
+// inMaintenanceWindow returns true when the given time is within the daily maintenance window.
+func inMaintenanceWindow(window kcpv1alpha2.MaintenanceWindow, now time.Time) bool {
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false
+	}
+
+	now = now.UTC()
+
+	opening := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	// The window opened the previous day could be still open, when crossing midnight.
+	if opening.After(now) {
+		opening = opening.AddDate(0, 0, -1)
+	}
+
+	return now.Before(opening.Add(window.Duration.Duration))
+}
+
+// reconcileResourceRecommendations samples the usage of the TenantControlPlane pods from the metrics.k8s.io API,
+// tracking the peak usage per component over the observation window: once elapsed, the recommended requests are
+// computed and, with the Auto mode, applied to the TenantControlPlane during the maintenance window.
+// The applied requests are picked up upon the TenantControlPlane update, triggered by the status change.
+// It returns the delay before the next usage sample.
+//
+//nolint:funlen,cyclop
+func (r *KamajiControlPlaneReconciler) reconcileResourceRecommendations(ctx context.Context, remoteClient client.Client, kcp kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, recommendations **kcpv1alpha2.KamajiControlPlaneResourceRecommendationsStatus, conditions *[]metav1.Condition) time.Duration {
+	log := ctrllog.FromContext(ctx)
+
+	spec := kcp.Spec.ResourceRecommendations
+	if spec == nil {
+		*recommendations = nil
+		meta.RemoveStatusCondition(conditions, string(kcpv1alpha2.ResourcesRecommendedConditionType))
+
+		return 0
+	}
+
+	condition := metav1.Condition{
+		Type:               string(kcpv1alpha2.ResourcesRecommendedConditionType),
+		ObservedGeneration: kcp.Generation,
+	}
+
+	usage, err := r.tenantControlPlaneUsage(ctx, remoteClient, tcp)
+	if err != nil {
+		log.Error(err, "unable to sample the TenantControlPlane pods usage")
+
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "MetricsUnavailable", err.Error()
+		meta.SetStatusCondition(conditions, condition)
+
+		return resourceUsageSamplePeriod
+	}
+
+	now := metav1.Now()
+
+	status := &kcpv1alpha2.KamajiControlPlaneResourceRecommendationsStatus{ObservationStartTime: now}
+	if *recommendations != nil {
+		status = (*recommendations).DeepCopy()
+	}
+
+	window := spec.ObservationWindow.Duration
+	if window == 0 {
+		window = defaultResourceObservationWindow
+	}
+
+	windowElapsed := now.Sub(status.ObservationStartTime.Time) >= window
+	if windowElapsed {
+		status.ObservationStartTime = now
+	}
+
+	apply := spec.Mode == kcpv1alpha2.ResourceRecommendationsModeAuto && spec.MaintenanceWindow != nil && inMaintenanceWindow(*spec.MaintenanceWindow, now.Time)
+
+	var applied []string
+
+	components := make([]kcpv1alpha2.KamajiControlPlaneComponentResourcesStatus, 0, len(controlPlaneComponentContainers))
+
+	for _, c := range controlPlaneComponentContainers {
+		component := kcpv1alpha2.KamajiControlPlaneComponentResourcesStatus{Name: c.component}
+		if index := slices.IndexFunc(status.Components, func(s kcpv1alpha2.KamajiControlPlaneComponentResourcesStatus) bool { return s.Name == c.component }); index >= 0 {
+			component = status.Components[index]
+		}
+
+		componentUsage, sampled := usage[c.component]
+		if !sampled && component.Recommended == nil && component.Applied == nil {
+			continue
+		}
+
+		component.PeakUsage = maxResourceList(component.PeakUsage, componentUsage)
+		// Recommending from the peak of the completed window, starting a new one from the current usage.
+		if windowElapsed {
+			component.Recommended = recommendRequests(component.PeakUsage, *spec, componentLimits(kcp, c.component))
+			component.PeakUsage = componentUsage.DeepCopy()
+		}
+
+		switch {
+		case spec.Mode != kcpv1alpha2.ResourceRecommendationsModeAuto:
+			component.Applied = nil
+		case apply && component.Recommended != nil && !equality.Semantic.DeepEqual(component.Applied, component.Recommended):
+			component.Applied = component.Recommended.DeepCopy()
+
+			applied = append(applied, c.component)
+		}
+
+		components = append(components, component)
+	}
+
+	status.Components = components
+
+	if len(applied) > 0 {
+		status.LastAppliedTime = ptr.To(now)
+
+		log.Info("resource recommendations applied", "components", applied)
+
+		r.recorder.Eventf(&kcp, tcp, corev1.EventTypeNormal, EventReasonResourcesRightSized, EventActionApply, "recommended requests applied to %s", strings.Join(applied, ", "))
+	}
+
+	*recommendations = status
+
+	condition.Status, condition.Reason = metav1.ConditionUnknown, "CollectingUsage"
+	condition.Message = fmt.Sprintf("observing the usage until %s", status.ObservationStartTime.Add(window).UTC().Format(time.RFC3339))
+
+	if slices.ContainsFunc(status.Components, func(s kcpv1alpha2.KamajiControlPlaneComponentResourcesStatus) bool { return s.Recommended != nil }) {
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionTrue, "Recommended", ""
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+
+	return resourceUsageSamplePeriod
+}
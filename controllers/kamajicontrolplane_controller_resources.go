@@ -5,13 +5,19 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -20,15 +26,35 @@ import (
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
 )
 
-//+kubebuilder:rbac:groups="",resources="secrets",verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources="secrets",verbs=get;list;watch;create;update;patch;delete
 
-func (r *KamajiControlPlaneReconciler) createRequiredResources(ctx context.Context, remoteClient client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) error {
+// Purposes of the Cluster API Secrets replicated from the Kamaji ones, used as name suffix.
+const (
+	secretPurposeKubeconfig     = "kubeconfig"
+	secretPurposeCA             = "ca"
+	secretPurposeServiceAccount = "sa"
+	secretPurposeFrontProxyCA   = "proxy"
+)
+
+// keyPairSecret describes a Kamaji Secret translated to a Cluster API one storing a key pair.
+type keyPairSecret struct {
+	purpose    string
+	sourceName string
+	certKey    string
+	keyKey     string
+}
+
+//nolint:cyclop
+func (r *KamajiControlPlaneReconciler) createRequiredResources(ctx context.Context, remoteClient client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, secrets *[]v1alpha2.KamajiControlPlaneSecretStatus) error {
 	log := ctrllog.FromContext(ctx)
 	// Creating a kubeconfig secret for the workload cluster.
 	if secretName := tcp.Status.KubeConfig.Admin.SecretName; len(secretName) == 0 {
 		log.Info("admin kubeconfig still unprocessed by Kamaji, unable to create kubeconfig secret for the workload cluster, enqueuing back")
 
-		return fmt.Errorf("admin kubeconfig still unprocessed by Kamaji, %w", ErrEnqueueBack)
+		err := fmt.Errorf("admin kubeconfig still unprocessed by Kamaji, %w", ErrEnqueueBack)
+		setSecretStatus(secrets, cluster, secretPurposeKubeconfig, "", err)
+
+		return err
 	}
 
 	reader := r.client
@@ -37,7 +63,10 @@ func (r *KamajiControlPlaneReconciler) createRequiredResources(ctx context.Conte
 		reader = remoteClient
 	}
 
-	if err := r.createOrUpdateKubeconfig(ctx, reader, cluster, kcp, tcp); err != nil {
+	checksum, err := r.createOrUpdateKubeconfig(ctx, reader, cluster, kcp, tcp)
+	r.recordSecretSync(&kcp, tcp, secrets, cluster, secretPurposeKubeconfig, checksum, err)
+
+	if err != nil {
 		log.Error(err, "unable to replicate kubeconfig secret for the workload cluster")
 
 		return err
@@ -46,23 +75,184 @@ func (r *KamajiControlPlaneReconciler) createRequiredResources(ctx context.Conte
 	if secretName := tcp.Status.Certificates.CA.SecretName; len(secretName) == 0 {
 		log.Info("CA still unprocessed by Kamaji, unable to create Certificate Authority secret for the workload cluster, enqueuing back")
 
-		return fmt.Errorf("CA still unprocessed by Kamaji, %w", ErrEnqueueBack)
+		err = fmt.Errorf("CA still unprocessed by Kamaji, %w", ErrEnqueueBack)
+		setSecretStatus(secrets, cluster, secretPurposeCA, "", err)
+
+		return err
+	}
+
+	checksum, err = r.createOrUpdateCertificateAuthority(ctx, reader, cluster, kcp, tcp)
+	// The Certificate Authority Secret managed by the Kamaji operator is not replicated, thus not tracked.
+	if checksum != "" || err != nil {
+		r.recordSecretSync(&kcp, tcp, secrets, cluster, secretPurposeCA, checksum, err)
 	}
 
-	if err := r.createOrUpdateCertificateAuthority(ctx, reader, cluster, kcp, tcp); err != nil {
+	if err != nil {
 		log.Error(err, "unable to replicate CA secret for the workload cluster")
 
 		return err
 	}
+	// Creating the service account key pair and the front-proxy CA secrets for the workload cluster:
+	// these are not required to provision the workload cluster, thus missing data is reported without enqueuing back.
+	// The etcd CA is not replicated since it belongs to the Kamaji DataStore, which is shared across Tenant Control Planes.
+	for _, pair := range []keyPairSecret{
+		{
+			purpose:    secretPurposeServiceAccount,
+			sourceName: tcp.Status.Certificates.SA.SecretName,
+			certKey:    "sa.pub",
+			keyKey:     "sa.key",
+		},
+		{
+			purpose:    secretPurposeFrontProxyCA,
+			sourceName: tcp.Status.Certificates.FrontProxyCA.SecretName,
+			certKey:    "front-proxy-ca.crt",
+			keyKey:     "front-proxy-ca.key",
+		},
+	} {
+		if len(pair.sourceName) == 0 {
+			setSecretStatus(secrets, cluster, pair.purpose, "", errors.Wrap(ErrMissingSecretData, "Secret still unprocessed by Kamaji"))
+
+			continue
+		}
+
+		checksum, err = r.createOrUpdateKeyPair(ctx, reader, cluster, kcp, tcp, pair)
+		r.recordSecretSync(&kcp, tcp, secrets, cluster, pair.purpose, checksum, err)
+
+		switch {
+		case errors.Is(err, ErrMissingSecretData):
+			log.Info("unable to replicate secret for the workload cluster", "purpose", pair.purpose, "reason", err.Error())
+		case err != nil:
+			log.Error(err, "unable to replicate secret for the workload cluster", "purpose", pair.purpose)
+
+			return err
+		}
+	}
 
 	return nil
 }
 
+// setSecretStatus reports the outcome of the replication of the Cluster API Secret with the given purpose,
+// returning true when the Secret has been synced with a changed Kamaji Secret.
+// The checksum and the last sync time are retained upon failures, reporting the last successful sync.
+func setSecretStatus(secrets *[]v1alpha2.KamajiControlPlaneSecretStatus, cluster capiv1beta2.Cluster, purpose, checksum string, err error) bool {
+	status := v1alpha2.KamajiControlPlaneSecretStatus{
+		Purpose: purpose,
+		Name:    cluster.Name + "-" + purpose,
+	}
+
+	index := slices.IndexFunc(*secrets, func(secret v1alpha2.KamajiControlPlaneSecretStatus) bool {
+		return secret.Purpose == purpose
+	})
+	if index >= 0 {
+		status.Checksum, status.LastSyncTime = (*secrets)[index].Checksum, (*secrets)[index].LastSyncTime
+	}
+
+	var synced bool
+
+	if err != nil {
+		status.Message = err.Error()
+	} else {
+		status.Ready = true
+
+		if status.Checksum != checksum {
+			status.Checksum, status.LastSyncTime, synced = checksum, ptr.To(metav1.Now()), true
+		}
+	}
+
+	if index >= 0 {
+		(*secrets)[index] = status
+	} else {
+		*secrets = append(*secrets, status)
+	}
+
+	return synced
+}
+
+// recordSecretSync reports the outcome of the replication of the Cluster API Secret,
+// emitting an Event when it has been synced with a changed Kamaji Secret.
+func (r *KamajiControlPlaneReconciler) recordSecretSync(kcp *v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, secrets *[]v1alpha2.KamajiControlPlaneSecretStatus, cluster capiv1beta2.Cluster, purpose, checksum string, err error) {
+	if !setSecretStatus(secrets, cluster, purpose, checksum, err) {
+		return
+	}
+
+	r.recorder.Eventf(kcp, tcp, corev1.EventTypeNormal, EventReasonSecretSynced, EventActionSync, "Secret %s-%s synced with the TenantControlPlane one (checksum %s)", cluster.Name, purpose, checksum)
+}
+
+// secretChecksum returns the checksum of the replicated Kamaji Secret values, in the given order.
+func secretChecksum(values ...[]byte) string {
+	hash := sha256.New()
+
+	for _, value := range values {
+		_, _ = fmt.Fprintf(hash, "%d:", len(value))
+		_, _ = hash.Write(value)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// createOrUpdateKeyPair takes care of translating a key pair corev1.Secret from Kamaji to CAPI expected resource,
+// such as the service account signing keys, or the front-proxy Certificate Authority.
+//
+// more info: https://cluster-api.sigs.k8s.io/developer/architecture/controllers/cluster.html#secrets
+func (r *KamajiControlPlaneReconciler) createOrUpdateKeyPair(ctx context.Context, reader client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, pair keyPairSecret) (string, error) {
+	kamajiSecret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: pair.sourceName, Namespace: tcp.Namespace}, kamajiSecret); err != nil {
+		return "", errors.Wrapf(err, "cannot retrieve source-of-truth for %s secret", pair.purpose)
+	}
+
+	crt, found := kamajiSecret.Data[pair.certKey]
+	if !found {
+		return "", errors.Wrapf(ErrMissingSecretData, "key %s not found in Secret %s", pair.certKey, pair.sourceName)
+	}
+
+	key, found := kamajiSecret.Data[pair.keyKey]
+	if !found {
+		return "", errors.Wrapf(ErrMissingSecretData, "key %s not found in Secret %s", pair.keyKey, pair.sourceName)
+	}
+
+	capiSecret := &corev1.Secret{}
+	capiSecret.Name = cluster.Name + "-" + pair.purpose
+	capiSecret.Namespace = cluster.Namespace
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, scopeErr := controllerutil.CreateOrUpdate(ctx, r.client, capiSecret, func() error {
+			labels := capiSecret.Labels
+			if labels == nil {
+				labels = map[string]string{}
+			}
+
+			labels[capiv1beta2.ClusterNameLabel] = cluster.Name
+			labels["kamaji.clastix.io/component"] = "capi"
+			labels["kamaji.clastix.io/secret"] = pair.purpose
+			labels["kamaji.clastix.io/cluster"] = cluster.Name
+			labels["kamaji.clastix.io/tcp"] = tcp.Name
+
+			capiSecret.SetLabels(labels)
+
+			capiSecret.Data = map[string][]byte{
+				corev1.TLSCertKey:       crt,
+				corev1.TLSPrivateKeyKey: key,
+			}
+			capiSecret.Type = capiv1beta2.ClusterSecretType
+
+			return controllerutil.SetControllerReference(&kcp, capiSecret, r.client.Scheme())
+		})
+
+		return scopeErr //nolint:wrapcheck
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot create or update %s secret", pair.purpose)
+	}
+
+	return secretChecksum(crt, key), nil
+}
+
 // createOrUpdateCertificateAuthority takes care of translating corev1.Secret from Kamaji to CAPI expected resource,
 // also in regard to the naming conventions according to the Cluster API contracts about Kubeconfig.
+// The returned checksum is empty when the Secret is managed by the Kamaji operator, and thus not replicated.
 //
 // more info: https://cluster-api.sigs.k8s.io/developer/architecture/controllers/cluster.html#secrets
-func (r *KamajiControlPlaneReconciler) createOrUpdateCertificateAuthority(ctx context.Context, reader client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) error {
+func (r *KamajiControlPlaneReconciler) createOrUpdateCertificateAuthority(ctx context.Context, reader client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
 	capiCA := &corev1.Secret{}
 	capiCA.Name = cluster.Name + "-ca"
 	capiCA.Namespace = cluster.Namespace
@@ -72,9 +262,11 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateCertificateAuthority(ctx co
 	kamajiCA.Namespace = tcp.Namespace
 
 	if err := reader.Get(ctx, types.NamespacedName{Name: kamajiCA.Name, Namespace: kamajiCA.Namespace}, kamajiCA); err != nil {
-		return errors.Wrap(err, "cannot retrieve source-of-truth as Certificate Authority")
+		return "", errors.Wrap(err, "cannot retrieve source-of-truth as Certificate Authority")
 	}
 
+	var checksum string
+
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		_, scopeErr := controllerutil.CreateOrUpdate(ctx, r.client, capiCA, func() error {
 			// Skipping the replication of the Certificate Authority if the Secret is managed by the Kamaji operator
@@ -105,6 +297,8 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateCertificateAuthority(ctx co
 
 			capiCA.SetLabels(labels)
 
+			checksum = secretChecksum(crt, key)
+
 			capiCA.Data = map[string][]byte{
 				corev1.TLSCertKey:       crt,
 				corev1.TLSPrivateKeyKey: key,
@@ -117,17 +311,54 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateCertificateAuthority(ctx co
 		return scopeErr //nolint:wrapcheck
 	})
 	if err != nil {
-		return errors.Wrap(err, "cannot create or update CA secret")
+		return "", errors.Wrap(err, "cannot create or update CA secret")
 	}
 
-	return nil
+	return checksum, nil
+}
+
+// adminKubeconfigSecretKey returns the key of the Kamaji admin kubeconfig Secret replicated for the workload cluster.
+func adminKubeconfigSecretKey(kcp v1alpha2.KamajiControlPlane) string {
+	if kcp.Spec.AdminKubeconfig != nil && kcp.Spec.AdminKubeconfig.SecretKey != "" {
+		return kcp.Spec.AdminKubeconfig.SecretKey
+	}
+
+	secretKey := "admin.conf"
+	if kcp.Spec.Network.AdvertiseAddress != "" {
+		secretKey = "admin.svc"
+	}
+
+	if v, ok := kcp.GetAnnotations()[kamajiv1alpha1.KubeconfigSecretKeyAnnotation]; ok && v != "" {
+		secretKey = v
+	}
+
+	return secretKey
+}
+
+// rewriteKubeconfigServer replaces the server URL of the clusters referenced by the kubeconfig.
+func rewriteKubeconfigServer(value []byte, server string) ([]byte, error) {
+	config, err := clientcmd.Load(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load admin kubeconfig")
+	}
+
+	for _, cluster := range config.Clusters {
+		cluster.Server = server
+	}
+
+	value, err = clientcmd.Write(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot serialize admin kubeconfig")
+	}
+
+	return value, nil
 }
 
 // createOrUpdateKubeconfig takes care of translating corev1.Secret from Kamaji to CAPI expected resource,
 // also in regard to the naming conventions according to the Cluster API contracts about kubeconfig.
 //
 // more info: https://cluster-api.sigs.k8s.io/developer/architecture/controllers/cluster.html#secrets
-func (r *KamajiControlPlaneReconciler) createOrUpdateKubeconfig(ctx context.Context, reader client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) error {
+func (r *KamajiControlPlaneReconciler) createOrUpdateKubeconfig(ctx context.Context, reader client.Client, cluster capiv1beta2.Cluster, kcp v1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
 	capiAdminKubeconfig := &corev1.Secret{}
 	capiAdminKubeconfig.Name = cluster.Name + "-kubeconfig"
 	capiAdminKubeconfig.Namespace = cluster.Namespace
@@ -137,9 +368,11 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateKubeconfig(ctx context.Cont
 	kamajiAdminKubeconfig.Namespace = tcp.Namespace
 
 	if err := reader.Get(ctx, types.NamespacedName{Name: kamajiAdminKubeconfig.Name, Namespace: kamajiAdminKubeconfig.Namespace}, kamajiAdminKubeconfig); err != nil {
-		return errors.Wrap(err, "cannot retrieve source-of-truth for admin kubeconfig")
+		return "", errors.Wrap(err, "cannot retrieve source-of-truth for admin kubeconfig")
 	}
 
+	var checksum string
+
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		_, scopeErr := controllerutil.CreateOrUpdate(ctx, r.client, capiAdminKubeconfig, func() error {
 			labels := capiAdminKubeconfig.Labels
@@ -153,19 +386,20 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateKubeconfig(ctx context.Cont
 			labels["kamaji.clastix.io/cluster"] = cluster.Name
 			labels["kamaji.clastix.io/tcp"] = tcp.Name
 
-			secretKey := "admin.conf"
-			if kcp.Spec.Network.AdvertiseAddress != "" {
-				secretKey = "admin.svc"
-			}
-			if v, ok := kcp.GetAnnotations()[kamajiv1alpha1.KubeconfigSecretKeyAnnotation]; ok && v != "" {
-				secretKey = v
-			}
-
-			value, ok := kamajiAdminKubeconfig.Data[secretKey]
+			value, ok := kamajiAdminKubeconfig.Data[adminKubeconfigSecretKey(kcp)]
 			if !ok {
 				return errors.New("missing key from *kamajiv1alpha1.TenantControlPlane admin kubeconfig secret")
 			}
 
+			if kcp.Spec.AdminKubeconfig != nil && kcp.Spec.AdminKubeconfig.Server != "" {
+				var rewriteErr error
+				if value, rewriteErr = rewriteKubeconfigServer(value, kcp.Spec.AdminKubeconfig.Server); rewriteErr != nil {
+					return rewriteErr
+				}
+			}
+
+			checksum = secretChecksum(value)
+
 			capiAdminKubeconfig.SetLabels(labels)
 
 			capiAdminKubeconfig.Data = map[string][]byte{
@@ -179,8 +413,8 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateKubeconfig(ctx context.Cont
 		return scopeErr //nolint:wrapcheck
 	})
 	if err != nil {
-		return errors.Wrap(err, "cannot create or update admin Kubeconfig secret")
+		return "", errors.Wrap(err, "cannot create or update admin Kubeconfig secret")
 	}
 
-	return nil
+	return checksum, nil
 }
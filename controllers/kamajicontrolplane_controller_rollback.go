@@ -0,0 +1,197 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/externalclusterreference"
+)
+
+const upgradeSnapshotSecretKey = "spec"
+
+var ErrMissingUpgradeSnapshot = errors.New("no last known-good TenantControlPlane snapshot is available")
+
+// upgradeSnapshotSecretName returns the name of the Secret storing the last known-good TenantControlPlane spec,
+// living in the KamajiControlPlane Namespace.
+func upgradeSnapshotSecretName(kcp kcpv1alpha2.KamajiControlPlane) string {
+	return kcp.GetName() + "-tcp-snapshot"
+}
+
+// isTenantControlPlaneKnownGood tells whether the TenantControlPlane spec has been proven healthy: Kamaji must report
+// the desired Kubernetes version as ready, along with a status refreshed after the latest TenantControlPlane update.
+func isTenantControlPlaneKnownGood(tcp *kamajiv1alpha1.TenantControlPlane, updateTime *metav1.Time) bool {
+	version := tcp.Status.Kubernetes.Version
+	if version.Status == nil || *version.Status != kamajiv1alpha1.VersionReady || version.Version != tcp.Spec.Kubernetes.Version {
+		return false
+	}
+
+	return updateTime == nil || !tcp.Status.Kubernetes.Deployment.LastUpdate.Before(updateTime)
+}
+
+// snapshotTenantControlPlane stores the spec of the healthy TenantControlPlane:
+// it's used to restore the previous state in case of an upgrade exceeding the progress deadline.
+func (r *KamajiControlPlaneReconciler) snapshotTenantControlPlane(ctx context.Context, kcp kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	spec, err := json.Marshal(tcp.Spec)
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal TenantControlPlane spec")
+	}
+
+	snapshot := &corev1.Secret{}
+	snapshot.Name = upgradeSnapshotSecretName(kcp)
+	snapshot.Namespace = kcp.GetNamespace()
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, scopeErr := controllerutil.CreateOrUpdate(ctx, r.client, snapshot, func() error {
+			labels := snapshot.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+
+			labels["kamaji.clastix.io/component"] = "capi"
+			labels["kamaji.clastix.io/secret"] = "snapshot"
+			labels["kamaji.clastix.io/tcp"] = tcp.Name
+
+			snapshot.SetLabels(labels)
+
+			snapshot.Data = map[string][]byte{
+				upgradeSnapshotSecretKey: spec,
+			}
+
+			return controllerutil.SetControllerReference(&kcp, snapshot, r.client.Scheme())
+		})
+
+		return scopeErr //nolint:wrapcheck
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot create or update TenantControlPlane snapshot")
+	}
+
+	return nil
+}
+
+// rollbackTenantControlPlane restores the last known-good spec of the TenantControlPlane.
+func (r *KamajiControlPlaneReconciler) rollbackTenantControlPlane(ctx context.Context, remoteClient client.Client, kcp kcpv1alpha2.KamajiControlPlane) error {
+	snapshot := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: upgradeSnapshotSecretName(kcp), Namespace: kcp.GetNamespace()}, snapshot); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ErrMissingUpgradeSnapshot
+		}
+
+		return errors.Wrap(err, "cannot retrieve TenantControlPlane snapshot")
+	}
+
+	var spec kamajiv1alpha1.TenantControlPlaneSpec
+	if err := json.Unmarshal(snapshot.Data[upgradeSnapshotSecretKey], &spec); err != nil {
+		return errors.Wrap(err, "cannot unmarshal TenantControlPlane snapshot")
+	}
+
+	k8sClient, tcp := r.client, &kamajiv1alpha1.TenantControlPlane{}
+	tcp.Name, tcp.Namespace = kcp.GetName(), kcp.GetNamespace()
+
+	if remoteClient != nil {
+		k8sClient = remoteClient
+		tcp.Name, tcp.Namespace = externalclusterreference.GenerateRemoteTenantControlPlaneNames(kcp)
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(tcp), tcp); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		tcp.Spec = spec
+
+		return k8sClient.Update(ctx, tcp)
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot restore TenantControlPlane snapshot")
+	}
+
+	return nil
+}
+
+// handleUpgradeFailure rolls back the TenantControlPlane upon a failed upgrade, if not yet done:
+// the TenantControlPlane is then left untouched until the failure is acknowledged.
+func (r *KamajiControlPlaneReconciler) handleUpgradeFailure(ctx context.Context, remoteClient client.Client, kcp *kcpv1alpha2.KamajiControlPlane, conditions *[]metav1.Condition) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx)
+
+	upgrade := kcp.Status.Upgrade
+	message := fmt.Sprintf("upgrade from %s to %s failed, set the %s annotation to allow further upgrades", upgrade.PreviousVersion, upgrade.TargetVersion, kcpv1alpha2.UpgradeFailureAcknowledgedAnnotation)
+
+	if upgrade.RollbackTime == nil {
+		err := r.rollbackTenantControlPlane(ctx, remoteClient, *kcp)
+
+		switch {
+		case errors.Is(err, ErrMissingUpgradeSnapshot):
+			log.Info("unable to roll back the failed upgrade, manual intervention is required", "reason", err.Error())
+
+			message = fmt.Sprintf("upgrade from %s to %s failed and cannot be rolled back since %s", upgrade.PreviousVersion, upgrade.TargetVersion, err.Error())
+
+			r.recorder.Eventf(kcp, nil, corev1.EventTypeWarning, EventReasonUpgradeRollbackFailed, EventActionRollback, "%s", message)
+		case err != nil:
+			log.Error(err, "unable to roll back the failed upgrade")
+
+			r.recorder.Eventf(kcp, nil, corev1.EventTypeWarning, EventReasonUpgradeRollbackFailed, EventActionRollback,
+				"cannot roll back the upgrade from %s to %s: %s", upgrade.PreviousVersion, upgrade.TargetVersion, err.Error())
+
+			return ctrl.Result{}, err
+		default:
+			log.Info("failed upgrade has been rolled back", "previousVersion", upgrade.PreviousVersion, "targetVersion", upgrade.TargetVersion)
+
+			r.recorder.Eventf(kcp, nil, corev1.EventTypeWarning, EventReasonUpgradeRolledBack, EventActionRollback,
+				"upgrade from %s to %s exceeded the progress deadline, TenantControlPlane has been restored to the last known-good spec", upgrade.PreviousVersion, upgrade.TargetVersion)
+
+			if err = r.updateKamajiControlPlaneStatus(ctx, kcp, func() {
+				kcp.Status.Upgrade.RollbackTime = ptr.To(metav1.Now())
+			}); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               string(kcpv1alpha2.UpgradeInProgressConditionType),
+		Status:             metav1.ConditionFalse,
+		Reason:             "UpgradeFailed",
+		Message:            message,
+		ObservedGeneration: kcp.Generation,
+	})
+
+	return ctrl.Result{}, nil
+}
+
+// acknowledgeUpgradeFailure clears the failed upgrade and removes the acknowledgement annotation,
+// allowing the KamajiControlPlane desired state to be pushed again to the TenantControlPlane.
+func (r *KamajiControlPlaneReconciler) acknowledgeUpgradeFailure(ctx context.Context, kcp *kcpv1alpha2.KamajiControlPlane) error {
+	if err := r.updateKamajiControlPlaneStatus(ctx, kcp, func() {
+		kcp.Status.Upgrade = nil
+	}); err != nil {
+		return err
+	}
+
+	if err := r.removeKamajiControlPlaneAnnotation(ctx, kcp, kcpv1alpha2.UpgradeFailureAcknowledgedAnnotation); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(kcp, nil, corev1.EventTypeNormal, EventReasonUpgradeFailureAcknowledged, EventActionReconcile, "upgrade failure has been acknowledged, further upgrades are allowed")
+
+	return nil
+}
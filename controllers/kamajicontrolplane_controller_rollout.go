@@ -0,0 +1,109 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+// isRolloutDue returns true when the rolloutAfter time has passed.
+func isRolloutDue(kcp kcpv1alpha2.KamajiControlPlane) bool {
+	return kcp.Spec.RolloutAfter != nil && !kcp.Spec.RolloutAfter.After(time.Now())
+}
+
+// rolloutPodAdditionalMetadata returns the Tenant Control Plane pods additional metadata,
+// along with the restart annotation once the rolloutAfter time has passed:
+// the annotation value is the rolloutAfter one, thus stable across reconciliations.
+func rolloutPodAdditionalMetadata(kcp kcpv1alpha2.KamajiControlPlane) kamajiv1alpha1.AdditionalMetadata {
+	metadata := kamajiv1alpha1.AdditionalMetadata{
+		Labels:      kcp.Spec.Deployment.PodAdditionalMetadata.Labels,
+		Annotations: maps.Clone(kcp.Spec.Deployment.PodAdditionalMetadata.Annotations),
+	}
+
+	if !isRolloutDue(kcp) {
+		return metadata
+	}
+
+	if metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string)
+	}
+
+	metadata.Annotations[kcpv1alpha2.RolloutRestartedAtAnnotation] = kcp.Spec.RolloutAfter.UTC().Format(time.RFC3339)
+
+	return metadata
+}
+
+// reconcileRolloutStatus tracks the restart of the Tenant Control Plane pods requested with the rolloutAfter field:
+// the rollout is considered completed once Kamaji updated the Deployment after the request,
+// and every replica is updated and available.
+func reconcileRolloutStatus(kcp *kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, conditions *[]metav1.Condition) {
+	if isRolloutDue(*kcp) && (kcp.Status.Rollout == nil || !kcp.Status.Rollout.After.Equal(kcp.Spec.RolloutAfter)) {
+		kcp.Status.Rollout = &kcpv1alpha2.KamajiControlPlaneRolloutStatus{
+			After:     *kcp.Spec.RolloutAfter,
+			StartTime: ptr.To(metav1.Now()),
+		}
+	}
+
+	rollout := kcp.Status.Rollout
+
+	if !rollout.IsInProgress() {
+		reason := "NoRollout"
+		if rollout != nil {
+			reason = "RolloutCompleted"
+		}
+
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.RolloutInProgressConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: kcp.Generation,
+		})
+
+		return
+	}
+
+	deployment := tcp.Status.Kubernetes.Deployment
+	desiredReplicas := ptr.Deref(kcp.Spec.Replicas, deployment.Replicas)
+	// Until the Deployment has been updated by Kamaji, the updated replicas are referring to the previous template.
+	observed := !deployment.LastUpdate.Before(rollout.StartTime)
+
+	rollout.UpdatedReplicas = 0
+	if observed {
+		rollout.UpdatedReplicas = deployment.UpdatedReplicas
+	}
+
+	if observed &&
+		deployment.UpdatedReplicas >= desiredReplicas &&
+		deployment.AvailableReplicas >= desiredReplicas &&
+		deployment.Replicas == deployment.UpdatedReplicas {
+		rollout.CompletionTime = ptr.To(metav1.Now())
+
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.RolloutInProgressConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             "RolloutCompleted",
+			Message:            fmt.Sprintf("%d replicas restarted", rollout.UpdatedReplicas),
+			ObservedGeneration: kcp.Generation,
+		})
+
+		return
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               string(kcpv1alpha2.RolloutInProgressConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             "Rolling",
+		Message:            fmt.Sprintf("%d of %d replicas restarted", rollout.UpdatedReplicas, desiredReplicas),
+		ObservedGeneration: kcp.Generation,
+	})
+}
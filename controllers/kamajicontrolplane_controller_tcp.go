@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -27,12 +28,15 @@ var ErrUnsupportedCertificateSAN = errors.New("a certificate SAN must be made of
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=get;list;watch;create;update
 
-//nolint:funlen,gocognit,cyclop,maintidx,gocyclo
-func (r *KamajiControlPlaneReconciler) createOrUpdateTenantControlPlane(ctx context.Context, remoteClient client.Client, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane) (*kamajiv1alpha1.TenantControlPlane, error) {
+// createOrUpdateTenantControlPlane reconciles the TenantControlPlane from the KamajiControlPlane declaration,
+// returning the fields drifted from it.
+func (r *KamajiControlPlaneReconciler) createOrUpdateTenantControlPlane(ctx context.Context, remoteClient client.Client, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane) (*kamajiv1alpha1.TenantControlPlane, []string, error) {
 	tcp := &kamajiv1alpha1.TenantControlPlane{}
 	tcp.Name = kcp.GetName()
 	tcp.Namespace = kcp.GetNamespace()
 
+	var drifted []string
+
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		k8sClient := r.client
 
@@ -44,238 +48,283 @@ func (r *KamajiControlPlaneReconciler) createOrUpdateTenantControlPlane(ctx cont
 		}
 
 		_, scopeErr := controllerutil.CreateOrUpdate(ctx, k8sClient, tcp, func() error {
-			if tcp.Annotations == nil {
-				tcp.Annotations = make(map[string]string)
+			var applyErr error
+			if drifted, applyErr = ApplyTenantControlPlane(tcp, cluster, kcp); applyErr != nil {
+				return applyErr
 			}
 
-			for k, v := range kcp.Annotations {
-				if k == corev1.LastAppliedConfigAnnotation {
-					continue
-				}
-
-				tcp.Annotations[k] = v
+			if !isDelegatedExternally {
+				return controllerutil.SetControllerReference(&kcp, tcp, k8sClient.Scheme())
 			}
 
-			tcp.Labels = kcp.Labels
+			return nil
+		})
 
-			if kubeconfigSecretKey := kcp.Annotations[kamajiv1alpha1.KubeconfigSecretKeyAnnotation]; kubeconfigSecretKey != "" {
-				tcp.Annotations[kamajiv1alpha1.KubeconfigSecretKeyAnnotation] = kubeconfigSecretKey
-			} else {
-				delete(tcp.Annotations, kamajiv1alpha1.KubeconfigSecretKeyAnnotation)
-			}
-			// TenantControlPlane port
-			if apiPort := cluster.Spec.ClusterNetwork.APIServerPort; apiPort != 0 {
-				tcp.Spec.NetworkProfile.Port = apiPort
-			}
-			// TenantControlPlane Services CIDR
-			if len(cluster.Spec.ClusterNetwork.Services.CIDRBlocks) > 0 {
-				tcp.Spec.NetworkProfile.ServiceCIDRs = append([]string{}, cluster.Spec.ClusterNetwork.Services.CIDRBlocks...)
-			}
-			// TenantControlPlane Pods CIDR
-			if len(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks) > 0 {
-				tcp.Spec.NetworkProfile.PodCIDRs = append([]string{}, cluster.Spec.ClusterNetwork.Pods.CIDRBlocks...)
-			}
-			// TenantControlPlane cluster domain
-			tcp.Spec.NetworkProfile.ClusterDomain = cluster.Spec.ClusterNetwork.ServiceDomain
-			// Replicas
-			tcp.Spec.ControlPlane.Deployment.Replicas = kcp.Spec.Replicas
-			// Version
-			// Tolerate version strings without a "v" prefix: prepend it if it's not there
-			if !strings.HasPrefix(kcp.Spec.Version, "v") {
-				tcp.Spec.Kubernetes.Version = "v" + kcp.Spec.Version
-			} else {
-				tcp.Spec.Kubernetes.Version = kcp.Spec.Version
-			}
-			// Set before CoreDNS addon to allow override.
-			tcp.Spec.NetworkProfile.DNSServiceIPs = kcp.Spec.Network.DNSServiceIPs
-			// Kamaji addons and CoreDNS overrides
-			tcp.Spec.Addons = kcp.Spec.Addons.AddonsSpec
-			if kcp.Spec.Addons.CoreDNS != nil {
-				tcp.Spec.NetworkProfile.DNSServiceIPs = kcp.Spec.Addons.CoreDNS.DNSServiceIPs
-
-				if kcp.Spec.Addons.CoreDNS.AddonSpec == nil {
-					kcp.Spec.Addons.CoreDNS.AddonSpec = &kamajiv1alpha1.AddonSpec{}
-				}
-
-				tcp.Spec.Addons.CoreDNS = kcp.Spec.Addons.CoreDNS.AddonSpec
-			}
-			// Kamaji specific options
-			if kcp.Spec.DataStoreName != "" {
-				tcp.Spec.DataStore = kcp.Spec.DataStoreName
-			}
-			if kcp.Spec.DataStoreSchema != "" {
-				tcp.Spec.DataStoreSchema = kcp.Spec.DataStoreSchema
-			}
-			if kcp.Spec.DataStoreUsername != "" {
-				tcp.Spec.DataStoreUsername = kcp.Spec.DataStoreUsername
-			}
-			tcp.Spec.DataStoreOverrides = kcp.Spec.DataStoreOverrides
-			tcp.Spec.Kubernetes.AdmissionControllers = kcp.Spec.AdmissionControllers
-			tcp.Spec.ControlPlane.Deployment.RegistrySettings.Registry = kcp.Spec.ContainerRegistry
-			// Volume mounts
-			if tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts == nil {
-				tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts = &kamajiv1alpha1.AdditionalVolumeMounts{}
-			}
+		return scopeErr //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot create or update TenantControlPlane")
+	}
 
-			tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts.ControllerManager = kcp.Spec.ControllerManager.ExtraVolumeMounts
-			tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts.Scheduler = kcp.Spec.Scheduler.ExtraVolumeMounts
-			tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts.APIServer = kcp.Spec.ApiServer.ExtraVolumeMounts
-			// Extra args
-			if tcp.Spec.ControlPlane.Deployment.ExtraArgs == nil {
-				tcp.Spec.ControlPlane.Deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{}
-			}
+	return tcp, drifted, nil
+}
 
-			tcp.Spec.ControlPlane.Deployment.ExtraArgs.ControllerManager = kcp.Spec.ControllerManager.ExtraArgs
-			tcp.Spec.ControlPlane.Deployment.ExtraArgs.Scheduler = kcp.Spec.Scheduler.ExtraArgs
-			tcp.Spec.ControlPlane.Deployment.ExtraArgs.APIServer = kcp.Spec.ApiServer.ExtraArgs
-			tcp.Spec.ControlPlane.Deployment.ExtraArgs.Kine = kcp.Spec.Kine.ExtraArgs
-			// Resources
-			if tcp.Spec.ControlPlane.Deployment.Resources == nil {
-				tcp.Spec.ControlPlane.Deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{}
-			}
+// ApplyTenantControlPlane applies the fields managed from the KamajiControlPlane and the Cluster onto the live
+// TenantControlPlane according to the drift policy, returning the fields drifted from them. The desired fields are
+// translated onto an empty TenantControlPlane, leaving the ones not declared to Kamaji, such as the defaulted ones.
+func ApplyTenantControlPlane(live *kamajiv1alpha1.TenantControlPlane, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane) ([]string, error) {
+	desired := &kamajiv1alpha1.TenantControlPlane{}
+	desired.Name, desired.Namespace = live.Name, live.Namespace
 
-			tcp.Spec.ControlPlane.Deployment.Resources.ControllerManager = &kcp.Spec.ControllerManager.Resources
-			tcp.Spec.ControlPlane.Deployment.Resources.Scheduler = &kcp.Spec.Scheduler.Resources
-			tcp.Spec.ControlPlane.Deployment.Resources.APIServer = &kcp.Spec.ApiServer.Resources
-			tcp.Spec.ControlPlane.Deployment.Resources.Kine = &kcp.Spec.Kine.Resources
-			// Container image overrides
-			tcp.Spec.ControlPlane.Deployment.RegistrySettings.ControllerManagerImage = kcp.Spec.ControllerManager.ContainerImageName
-			tcp.Spec.ControlPlane.Deployment.RegistrySettings.SchedulerImage = kcp.Spec.Scheduler.ContainerImageName
-			tcp.Spec.ControlPlane.Deployment.RegistrySettings.APIServerImage = kcp.Spec.ApiServer.ContainerImageName
-			// Kubelet
-			tcp.Spec.Kubernetes.Kubelet = kcp.Spec.Kubelet
-			// Network
-			tcp.Spec.NetworkProfile.Address = kcp.Spec.Network.ServiceAddress
-			tcp.Spec.NetworkProfile.AdvertiseAddress = kcp.Spec.Network.AdvertiseAddress
-			tcp.Spec.ControlPlane.Service.ServiceType = kcp.Spec.Network.ServiceType
-			tcp.Spec.ControlPlane.Service.AdditionalMetadata.Labels = kcp.Spec.Network.ServiceLabels
-			tcp.Spec.ControlPlane.Service.AdditionalMetadata.Annotations = kcp.Spec.Network.ServiceAnnotations
-			tcp.Spec.ControlPlane.Service.AdditionalPorts = kcp.Spec.Network.AdditionalServicePorts
-
-			for _, i := range kcp.Spec.Network.CertSANs {
-				// validating CertSANs as soon as possible to avoid github.com/clastix/kamaji/issues/679:
-				// nil err means the entry is in the form of <HOST>:<PORT> which is not accepted
-				if _, _, err := net.SplitHostPort(i); err == nil {
-					return errors.Wrap(ErrUnsupportedCertificateSAN, fmt.Sprintf("entry %s is invalid", i))
-				}
-			}
+	if err := TranslateTenantControlPlane(desired, cluster, kcp); err != nil {
+		return nil, err
+	}
 
-			tcp.Spec.NetworkProfile.CertSANs = kcp.Spec.Network.CertSANs
-			// GatewayAPI
-			if kcp.Spec.Network.Gateway != nil { //nolint:nestif
-				// In the case of enabled gateway, adding the FQDN to the CertSANs
-				if tcp.Spec.NetworkProfile.CertSANs == nil {
-					tcp.Spec.NetworkProfile.CertSANs = []string{}
-				}
-
-				host, _, err := net.SplitHostPort(kcp.Spec.Network.Gateway.Hostname)
-				if err != nil {
-					// No port specification, adding bare entry
-					host = kcp.Spec.Network.Gateway.Hostname
-				}
-				tcp.Spec.NetworkProfile.CertSANs = append(tcp.Spec.NetworkProfile.CertSANs, host)
-				parentRef := gatewayv1.ParentReference{
-					Name:      gatewayv1.ObjectName(kcp.Spec.Network.Gateway.Name),
-					Namespace: ptr.To(gatewayv1.Namespace(kcp.Spec.Network.Gateway.Namespace)),
-				}
-				if sectionName := kcp.Spec.Network.Gateway.SectionName; sectionName != "" {
-					parentRef.SectionName = ptr.To(gatewayv1.SectionName(sectionName))
-				}
-				if port := kcp.Spec.Network.Gateway.Port; port != nil {
-					parentRef.Port = ptr.To(*port)
-				}
-				tcp.Spec.ControlPlane.Gateway = &kamajiv1alpha1.GatewaySpec{
-					Hostname:          gatewayv1.Hostname(host),
-					GatewayParentRefs: []gatewayv1.ParentReference{parentRef},
-					AdditionalMetadata: kamajiv1alpha1.AdditionalMetadata{
-						Labels:      kcp.Spec.Network.Gateway.ExtraLabels,
-						Annotations: kcp.Spec.Network.Gateway.ExtraAnnotations,
-					},
-				}
-			} else {
-				tcp.Spec.ControlPlane.Gateway = nil
-			}
-			// Ingress
-			if kcp.Spec.Network.Ingress != nil {
-				tcp.Spec.ControlPlane.Ingress = &kamajiv1alpha1.IngressSpec{
-					AdditionalMetadata: kamajiv1alpha1.AdditionalMetadata{
-						Labels:      kcp.Spec.Network.Ingress.ExtraLabels,
-						Annotations: kcp.Spec.Network.Ingress.ExtraAnnotations,
-					},
-					IngressClassName: kcp.Spec.Network.Ingress.ClassName,
-					Hostname:         kcp.Spec.Network.Ingress.Hostname,
-				}
-				// In the case of enabled ingress, adding the FQDN to the CertSANs
-				if tcp.Spec.NetworkProfile.CertSANs == nil {
-					tcp.Spec.NetworkProfile.CertSANs = []string{}
-				}
-
-				if host, _, err := net.SplitHostPort(kcp.Spec.Network.Ingress.Hostname); err == nil {
-					// no error means <FQDN>:<PORT>, we need the host variable
-					tcp.Spec.NetworkProfile.CertSANs = append(tcp.Spec.NetworkProfile.CertSANs, host)
-				} else {
-					// No port specification, adding bare entry
-					tcp.Spec.NetworkProfile.CertSANs = append(tcp.Spec.NetworkProfile.CertSANs, kcp.Spec.Network.Ingress.Hostname)
-				}
-			} else {
-				tcp.Spec.ControlPlane.Ingress = nil
-			}
-			// LoadBalancer
-			if kcp.Spec.Network.LoadBalancerConfig != nil {
-				if lbClass := kcp.Spec.Network.LoadBalancerConfig.LoadBalancerClass; lbClass != nil {
-					tcp.Spec.NetworkProfile.LoadBalancerClass = ptr.To(*lbClass)
-				}
-
-				if srcRange := kcp.Spec.Network.LoadBalancerConfig.LoadBalancerSourceRanges; srcRange != nil {
-					tcp.Spec.NetworkProfile.LoadBalancerSourceRanges = srcRange
-				}
-			}
+	return reconcileTenantControlPlaneDrift(live, desired, kcp.Spec.DriftPolicy)
+}
 
-			// Deployment
-			tcp.Spec.ControlPlane.Deployment.NodeSelector = kcp.Spec.Deployment.NodeSelector
-			tcp.Spec.ControlPlane.Deployment.RuntimeClassName = kcp.Spec.Deployment.RuntimeClassName
-			tcp.Spec.ControlPlane.Deployment.ServiceAccountName = kcp.Spec.Deployment.ServiceAccountName
-			tcp.Spec.ControlPlane.Deployment.AdditionalMetadata = kcp.Spec.Deployment.AdditionalMetadata
-			tcp.Spec.ControlPlane.Deployment.PodAdditionalMetadata = kcp.Spec.Deployment.PodAdditionalMetadata
-			tcp.Spec.ControlPlane.Deployment.Strategy = kcp.Spec.Deployment.Strategy
-			tcp.Spec.ControlPlane.Deployment.Affinity = kcp.Spec.Deployment.Affinity
-			tcp.Spec.ControlPlane.Deployment.Tolerations = kcp.Spec.Deployment.Tolerations
-			tcp.Spec.ControlPlane.Deployment.TopologySpreadConstraints = kcp.Spec.Deployment.TopologySpreadConstraints
-			tcp.Spec.ControlPlane.Deployment.AdditionalInitContainers = kcp.Spec.Deployment.ExtraInitContainers
-			tcp.Spec.ControlPlane.Deployment.AdditionalContainers = kcp.Spec.Deployment.ExtraContainers
-			tcp.Spec.ControlPlane.Deployment.AdditionalVolumes = kcp.Spec.Deployment.ExtraVolumes
-
-			if kcp.Spec.Deployment.Probes == nil ||
-				kcp.Spec.ApiServer.Probes == nil ||
-				kcp.Spec.ControllerManager.Probes == nil ||
-				kcp.Spec.Scheduler.Probes == nil {
-				tcp.Spec.ControlPlane.Deployment.Probes = nil
-			} else {
-				tcp.Spec.ControlPlane.Deployment.Probes = &kamajiv1alpha1.ControlPlaneProbes{
-					APIServer:         kcp.Spec.ApiServer.Probes,
-					ControllerManager: kcp.Spec.ControllerManager.Probes,
-					Scheduler:         kcp.Spec.Scheduler.Probes,
-				}
-
-				if kcp.Spec.Deployment.Probes != nil {
-					tcp.Spec.ControlPlane.Deployment.Probes.Liveness = kcp.Spec.Deployment.Probes.Liveness
-					tcp.Spec.ControlPlane.Deployment.Probes.Readiness = kcp.Spec.Deployment.Probes.Readiness
-					tcp.Spec.ControlPlane.Deployment.Probes.Startup = kcp.Spec.Deployment.Probes.Startup
-				}
-			}
+// TranslateTenantControlPlane applies the KamajiControlPlane and the Cluster declarations onto the given
+// TenantControlPlane, retaining the fields not managed by the provider, such as the defaulted ones.
+// It has no side effects besides the TenantControlPlane mutation, allowing to render it offline.
+//
+//nolint:funlen,gocognit,cyclop,maintidx,gocyclo
+func TranslateTenantControlPlane(tcp *kamajiv1alpha1.TenantControlPlane, cluster capiv1beta2.Cluster, kcp kcpv1alpha2.KamajiControlPlane) error {
+	if tcp.Annotations == nil {
+		tcp.Annotations = make(map[string]string)
+	}
 
-			if !isDelegatedExternally {
-				return controllerutil.SetControllerReference(&kcp, tcp, k8sClient.Scheme())
-			}
+	// Removing the annotations previously propagated, although no longer declared on the KamajiControlPlane.
+	for _, k := range strings.Split(tcp.Annotations[kcpv1alpha2.PropagatedAnnotationsAnnotation], ",") {
+		if _, ok := kcp.Annotations[k]; !ok {
+			delete(tcp.Annotations, k)
+		}
+	}
 
-			return nil
-		})
+	propagated := make([]string, 0, len(kcp.Annotations))
 
-		return scopeErr //nolint:wrapcheck
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot create or update TenantControlPlane")
+	for k, v := range kcp.Annotations {
+		if k == corev1.LastAppliedConfigAnnotation {
+			continue
+		}
+
+		tcp.Annotations[k] = v
+		propagated = append(propagated, k)
+	}
+
+	slices.Sort(propagated)
+
+	if len(propagated) > 0 {
+		tcp.Annotations[kcpv1alpha2.PropagatedAnnotationsAnnotation] = strings.Join(propagated, ",")
+	} else {
+		delete(tcp.Annotations, kcpv1alpha2.PropagatedAnnotationsAnnotation)
+	}
+
+	tcp.Labels = kcp.Labels
+
+	kubeconfigSecretKey := kcp.Annotations[kamajiv1alpha1.KubeconfigSecretKeyAnnotation]
+	if kcp.Spec.AdminKubeconfig != nil && kcp.Spec.AdminKubeconfig.SecretKey != "" {
+		kubeconfigSecretKey = kcp.Spec.AdminKubeconfig.SecretKey
+	}
+
+	if kubeconfigSecretKey != "" {
+		tcp.Annotations[kamajiv1alpha1.KubeconfigSecretKeyAnnotation] = kubeconfigSecretKey
+	} else {
+		delete(tcp.Annotations, kamajiv1alpha1.KubeconfigSecretKeyAnnotation)
+	}
+	// TenantControlPlane port
+	if apiPort := cluster.Spec.ClusterNetwork.APIServerPort; apiPort != 0 {
+		tcp.Spec.NetworkProfile.Port = apiPort
+	}
+	// TenantControlPlane Services CIDR
+	if len(cluster.Spec.ClusterNetwork.Services.CIDRBlocks) > 0 {
+		tcp.Spec.NetworkProfile.ServiceCIDRs = append([]string{}, cluster.Spec.ClusterNetwork.Services.CIDRBlocks...)
+	}
+	// TenantControlPlane Pods CIDR
+	if len(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks) > 0 {
+		tcp.Spec.NetworkProfile.PodCIDRs = append([]string{}, cluster.Spec.ClusterNetwork.Pods.CIDRBlocks...)
+	}
+	// TenantControlPlane cluster domain
+	tcp.Spec.NetworkProfile.ClusterDomain = cluster.Spec.ClusterNetwork.ServiceDomain
+	// Replicas
+	tcp.Spec.ControlPlane.Deployment.Replicas = kcp.Spec.Replicas
+	// Version
+	tcp.Spec.Kubernetes.Version = normalizeVersion(kcp.Spec.Version)
+	// Set before CoreDNS addon to allow override.
+	tcp.Spec.NetworkProfile.DNSServiceIPs = kcp.Spec.Network.DNSServiceIPs
+	// Kamaji addons and CoreDNS overrides
+	tcp.Spec.Addons = kcp.Spec.Addons.AddonsSpec
+	if kcp.Spec.Addons.CoreDNS != nil {
+		tcp.Spec.NetworkProfile.DNSServiceIPs = kcp.Spec.Addons.CoreDNS.DNSServiceIPs
+
+		tcp.Spec.Addons.CoreDNS = kcp.Spec.Addons.CoreDNS.AddonSpec
+		if tcp.Spec.Addons.CoreDNS == nil {
+			tcp.Spec.Addons.CoreDNS = &kamajiv1alpha1.AddonSpec{}
+		}
+	}
+	// Kamaji specific options
+	if kcp.Spec.DataStoreName != "" {
+		tcp.Spec.DataStore = kcp.Spec.DataStoreName
+	}
+	if kcp.Spec.DataStoreSchema != "" {
+		tcp.Spec.DataStoreSchema = kcp.Spec.DataStoreSchema
+	}
+	if kcp.Spec.DataStoreUsername != "" {
+		tcp.Spec.DataStoreUsername = kcp.Spec.DataStoreUsername
+	}
+	tcp.Spec.DataStoreOverrides = kcp.Spec.DataStoreOverrides
+	tcp.Spec.Kubernetes.AdmissionControllers = kcp.Spec.AdmissionControllers
+	tcp.Spec.ControlPlane.Deployment.RegistrySettings.Registry = kcp.Spec.ContainerRegistry
+	// Volume mounts
+	if tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts == nil {
+		tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts = &kamajiv1alpha1.AdditionalVolumeMounts{}
+	}
+
+	tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts.ControllerManager = kcp.Spec.ControllerManager.ExtraVolumeMounts
+	tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts.Scheduler = kcp.Spec.Scheduler.ExtraVolumeMounts
+	tcp.Spec.ControlPlane.Deployment.AdditionalVolumeMounts.APIServer = kcp.Spec.ApiServer.ExtraVolumeMounts
+	// Extra args
+	if tcp.Spec.ControlPlane.Deployment.ExtraArgs == nil {
+		tcp.Spec.ControlPlane.Deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{}
+	}
+
+	tcp.Spec.ControlPlane.Deployment.ExtraArgs.ControllerManager = kcp.Spec.ControllerManager.ExtraArgs
+	tcp.Spec.ControlPlane.Deployment.ExtraArgs.Scheduler = kcp.Spec.Scheduler.ExtraArgs
+	tcp.Spec.ControlPlane.Deployment.ExtraArgs.APIServer = kcp.Spec.ApiServer.ExtraArgs
+	tcp.Spec.ControlPlane.Deployment.ExtraArgs.Kine = kcp.Spec.Kine.ExtraArgs
+	// Resources
+	if tcp.Spec.ControlPlane.Deployment.Resources == nil {
+		tcp.Spec.ControlPlane.Deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{}
+	}
+
+	tcp.Spec.ControlPlane.Deployment.Resources.ControllerManager = componentResources(kcp, "controllerManager", kcp.Spec.ControllerManager.Resources)
+	tcp.Spec.ControlPlane.Deployment.Resources.Scheduler = componentResources(kcp, "scheduler", kcp.Spec.Scheduler.Resources)
+	tcp.Spec.ControlPlane.Deployment.Resources.APIServer = componentResources(kcp, "apiServer", kcp.Spec.ApiServer.Resources)
+	tcp.Spec.ControlPlane.Deployment.Resources.Kine = componentResources(kcp, "kine", kcp.Spec.Kine.Resources)
+	// Container image overrides
+	tcp.Spec.ControlPlane.Deployment.RegistrySettings.ControllerManagerImage = kcp.Spec.ControllerManager.ContainerImageName
+	tcp.Spec.ControlPlane.Deployment.RegistrySettings.SchedulerImage = kcp.Spec.Scheduler.ContainerImageName
+	tcp.Spec.ControlPlane.Deployment.RegistrySettings.APIServerImage = kcp.Spec.ApiServer.ContainerImageName
+	// Kubelet
+	tcp.Spec.Kubernetes.Kubelet = kcp.Spec.Kubelet
+	// Network
+	tcp.Spec.NetworkProfile.Address = kcp.Spec.Network.ServiceAddress
+	tcp.Spec.NetworkProfile.AdvertiseAddress = kcp.Spec.Network.AdvertiseAddress
+	tcp.Spec.ControlPlane.Service.ServiceType = kcp.Spec.Network.ServiceType
+	tcp.Spec.ControlPlane.Service.AdditionalMetadata.Labels = kcp.Spec.Network.ServiceLabels
+	tcp.Spec.ControlPlane.Service.AdditionalMetadata.Annotations = kcp.Spec.Network.ServiceAnnotations
+	tcp.Spec.ControlPlane.Service.AdditionalPorts = kcp.Spec.Network.AdditionalServicePorts
+
+	for _, i := range kcp.Spec.Network.CertSANs {
+		// validating CertSANs as soon as possible to avoid github.com/clastix/kamaji/issues/679:
+		// nil err means the entry is in the form of <HOST>:<PORT> which is not accepted
+		if _, _, err := net.SplitHostPort(i); err == nil {
+			return errors.Wrap(ErrUnsupportedCertificateSAN, fmt.Sprintf("entry %s is invalid", i))
+		}
+	}
+
+	tcp.Spec.NetworkProfile.CertSANs = kcp.Spec.Network.CertSANs
+	// GatewayAPI
+	if kcp.Spec.Network.Gateway != nil { //nolint:nestif
+		// In the case of enabled gateway, adding the FQDN to the CertSANs
+		if tcp.Spec.NetworkProfile.CertSANs == nil {
+			tcp.Spec.NetworkProfile.CertSANs = []string{}
+		}
+
+		host, _, err := net.SplitHostPort(kcp.Spec.Network.Gateway.Hostname)
+		if err != nil {
+			// No port specification, adding bare entry
+			host = kcp.Spec.Network.Gateway.Hostname
+		}
+		tcp.Spec.NetworkProfile.CertSANs = append(tcp.Spec.NetworkProfile.CertSANs, host)
+		parentRef := gatewayv1.ParentReference{
+			Name:      gatewayv1.ObjectName(kcp.Spec.Network.Gateway.Name),
+			Namespace: ptr.To(gatewayv1.Namespace(kcp.Spec.Network.Gateway.Namespace)),
+		}
+		if sectionName := kcp.Spec.Network.Gateway.SectionName; sectionName != "" {
+			parentRef.SectionName = ptr.To(gatewayv1.SectionName(sectionName))
+		}
+		if port := kcp.Spec.Network.Gateway.Port; port != nil {
+			parentRef.Port = ptr.To(*port)
+		}
+		tcp.Spec.ControlPlane.Gateway = &kamajiv1alpha1.GatewaySpec{
+			Hostname:          gatewayv1.Hostname(host),
+			GatewayParentRefs: []gatewayv1.ParentReference{parentRef},
+			AdditionalMetadata: kamajiv1alpha1.AdditionalMetadata{
+				Labels:      kcp.Spec.Network.Gateway.ExtraLabels,
+				Annotations: kcp.Spec.Network.Gateway.ExtraAnnotations,
+			},
+		}
+	} else {
+		tcp.Spec.ControlPlane.Gateway = nil
+	}
+	// Ingress
+	if kcp.Spec.Network.Ingress != nil {
+		tcp.Spec.ControlPlane.Ingress = &kamajiv1alpha1.IngressSpec{
+			AdditionalMetadata: kamajiv1alpha1.AdditionalMetadata{
+				Labels:      kcp.Spec.Network.Ingress.ExtraLabels,
+				Annotations: kcp.Spec.Network.Ingress.ExtraAnnotations,
+			},
+			IngressClassName: kcp.Spec.Network.Ingress.ClassName,
+			Hostname:         kcp.Spec.Network.Ingress.Hostname,
+		}
+		// In the case of enabled ingress, adding the FQDN to the CertSANs
+		if tcp.Spec.NetworkProfile.CertSANs == nil {
+			tcp.Spec.NetworkProfile.CertSANs = []string{}
+		}
+
+		if host, _, err := net.SplitHostPort(kcp.Spec.Network.Ingress.Hostname); err == nil {
+			// no error means <FQDN>:<PORT>, we need the host variable
+			tcp.Spec.NetworkProfile.CertSANs = append(tcp.Spec.NetworkProfile.CertSANs, host)
+		} else {
+			// No port specification, adding bare entry
+			tcp.Spec.NetworkProfile.CertSANs = append(tcp.Spec.NetworkProfile.CertSANs, kcp.Spec.Network.Ingress.Hostname)
+		}
+	} else {
+		tcp.Spec.ControlPlane.Ingress = nil
+	}
+	// LoadBalancer
+	if kcp.Spec.Network.LoadBalancerConfig != nil {
+		if lbClass := kcp.Spec.Network.LoadBalancerConfig.LoadBalancerClass; lbClass != nil {
+			tcp.Spec.NetworkProfile.LoadBalancerClass = ptr.To(*lbClass)
+		}
+
+		if srcRange := kcp.Spec.Network.LoadBalancerConfig.LoadBalancerSourceRanges; srcRange != nil {
+			tcp.Spec.NetworkProfile.LoadBalancerSourceRanges = srcRange
+		}
+	}
+
+	// Deployment
+	tcp.Spec.ControlPlane.Deployment.NodeSelector = kcp.Spec.Deployment.NodeSelector
+	tcp.Spec.ControlPlane.Deployment.RuntimeClassName = kcp.Spec.Deployment.RuntimeClassName
+	tcp.Spec.ControlPlane.Deployment.ServiceAccountName = kcp.Spec.Deployment.ServiceAccountName
+	tcp.Spec.ControlPlane.Deployment.AdditionalMetadata = kcp.Spec.Deployment.AdditionalMetadata
+	tcp.Spec.ControlPlane.Deployment.PodAdditionalMetadata = rolloutPodAdditionalMetadata(kcp)
+	tcp.Spec.ControlPlane.Deployment.Strategy = kcp.Spec.Deployment.Strategy
+	tcp.Spec.ControlPlane.Deployment.Affinity = kcp.Spec.Deployment.Affinity
+	tcp.Spec.ControlPlane.Deployment.Tolerations = kcp.Spec.Deployment.Tolerations
+	tcp.Spec.ControlPlane.Deployment.TopologySpreadConstraints = kcp.Spec.Deployment.TopologySpreadConstraints
+	tcp.Spec.ControlPlane.Deployment.AdditionalInitContainers = kcp.Spec.Deployment.ExtraInitContainers
+	tcp.Spec.ControlPlane.Deployment.AdditionalContainers = kcp.Spec.Deployment.ExtraContainers
+	tcp.Spec.ControlPlane.Deployment.AdditionalVolumes = kcp.Spec.Deployment.ExtraVolumes
+
+	if kcp.Spec.Deployment.Probes == nil ||
+		kcp.Spec.ApiServer.Probes == nil ||
+		kcp.Spec.ControllerManager.Probes == nil ||
+		kcp.Spec.Scheduler.Probes == nil {
+		tcp.Spec.ControlPlane.Deployment.Probes = nil
+	} else {
+		tcp.Spec.ControlPlane.Deployment.Probes = &kamajiv1alpha1.ControlPlaneProbes{
+			APIServer:         kcp.Spec.ApiServer.Probes,
+			ControllerManager: kcp.Spec.ControllerManager.Probes,
+			Scheduler:         kcp.Spec.Scheduler.Probes,
+		}
+
+		if kcp.Spec.Deployment.Probes != nil {
+			tcp.Spec.ControlPlane.Deployment.Probes.Liveness = kcp.Spec.Deployment.Probes.Liveness
+			tcp.Spec.ControlPlane.Deployment.Probes.Readiness = kcp.Spec.Deployment.Probes.Readiness
+			tcp.Spec.ControlPlane.Deployment.Probes.Startup = kcp.Spec.Deployment.Probes.Startup
+		}
 	}
 
-	return tcp, nil
+	return nil
 }
@@ -0,0 +1,155 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	. "github.com/onsi/gomega" //nolint:revive
+	"k8s.io/utils/ptr"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+//nolint:funlen
+func TestTranslateTenantControlPlane(t *testing.T) {
+	probes := &kamajiv1alpha1.ProbeSet{Liveness: &kamajiv1alpha1.ProbeSpec{PeriodSeconds: ptr.To(int32(10))}}
+
+	tests := []struct {
+		name   string
+		mutate func(kcp *kcpv1alpha2.KamajiControlPlane)
+		err    error
+		assert func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane)
+	}{
+		{
+			name: "network DNS service IPs",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Network.DNSServiceIPs = []string{"10.96.0.10"}
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.NetworkProfile.DNSServiceIPs).To(Equal([]string{"10.96.0.10"}))
+				g.Expect(tcp.Spec.Addons.CoreDNS).To(BeNil())
+			},
+		},
+		{
+			name: "CoreDNS addon DNS service IPs override",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Network.DNSServiceIPs = []string{"10.96.0.10"}
+				kcp.Spec.Addons.CoreDNS = &kcpv1alpha2.CoreDNSAddonSpec{DNSServiceIPs: []string{"10.96.0.53"}}
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.NetworkProfile.DNSServiceIPs).To(Equal([]string{"10.96.0.53"}))
+				g.Expect(tcp.Spec.Addons.CoreDNS).To(Equal(&kamajiv1alpha1.AddonSpec{}))
+			},
+		},
+		{
+			name: "CoreDNS addon with no DNS service IPs",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Network.DNSServiceIPs = []string{"10.96.0.10"}
+				kcp.Spec.Addons.CoreDNS = &kcpv1alpha2.CoreDNSAddonSpec{}
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.NetworkProfile.DNSServiceIPs).To(BeEmpty())
+				g.Expect(tcp.Spec.Addons.CoreDNS).NotTo(BeNil())
+			},
+		},
+		{
+			name: "probes of every component",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Deployment.Probes = &kamajiv1alpha1.ProbeSet{Startup: &kamajiv1alpha1.ProbeSpec{PeriodSeconds: ptr.To(int32(5))}}
+				kcp.Spec.ApiServer.Probes = probes
+				kcp.Spec.ControllerManager.Probes = probes
+				kcp.Spec.Scheduler.Probes = probes
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.ControlPlane.Deployment.Probes).To(Equal(&kamajiv1alpha1.ControlPlaneProbes{
+					Startup:           &kamajiv1alpha1.ProbeSpec{PeriodSeconds: ptr.To(int32(5))},
+					APIServer:         probes,
+					ControllerManager: probes,
+					Scheduler:         probes,
+				}))
+			},
+		},
+		{
+			name: "probes missing the deployment ones",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.ApiServer.Probes = probes
+				kcp.Spec.ControllerManager.Probes = probes
+				kcp.Spec.Scheduler.Probes = probes
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.ControlPlane.Deployment.Probes).To(BeNil())
+			},
+		},
+		{
+			name: "probes missing the scheduler ones",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Deployment.Probes = probes
+				kcp.Spec.ApiServer.Probes = probes
+				kcp.Spec.ControllerManager.Probes = probes
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.ControlPlane.Deployment.Probes).To(BeNil())
+			},
+		},
+		{
+			name: "ingress hostname added to the certificate SANs",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Network.CertSANs = []string{"api.example.com"}
+				kcp.Spec.Network.Ingress = &kcpv1alpha2.IngressComponent{Hostname: "tenant.example.com:443"}
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.NetworkProfile.CertSANs).To(Equal([]string{"api.example.com", "tenant.example.com"}))
+				g.Expect(tcp.Spec.ControlPlane.Ingress).NotTo(BeNil())
+				g.Expect(tcp.Spec.ControlPlane.Ingress.Hostname).To(Equal("tenant.example.com:443"))
+			},
+		},
+		{
+			name: "gateway hostname added to the certificate SANs",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Network.Gateway = &kcpv1alpha2.GatewayComponent{Name: "gateway", Namespace: "gateway-system", Hostname: "tenant.example.com"}
+			},
+			assert: func(g *WithT, tcp *kamajiv1alpha1.TenantControlPlane) {
+				g.Expect(tcp.Spec.NetworkProfile.CertSANs).To(Equal([]string{"tenant.example.com"}))
+				g.Expect(tcp.Spec.ControlPlane.Gateway).NotTo(BeNil())
+				g.Expect(string(tcp.Spec.ControlPlane.Gateway.Hostname)).To(Equal("tenant.example.com"))
+			},
+		},
+		{
+			name: "certificate SAN with a port",
+			mutate: func(kcp *kcpv1alpha2.KamajiControlPlane) {
+				kcp.Spec.Network.CertSANs = []string{"api.example.com:6443"}
+			},
+			err: ErrUnsupportedCertificateSAN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var cluster capiv1beta2.Cluster
+			cluster.Spec.ClusterNetwork.ServiceDomain = "cluster.local"
+
+			var kcp kcpv1alpha2.KamajiControlPlane
+			kcp.Name, kcp.Namespace = "tenant", "default"
+			kcp.Spec.Version = "v1.33.0"
+			tt.mutate(&kcp)
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+
+			err := TranslateTenantControlPlane(tcp, cluster, kcp)
+			if tt.err != nil {
+				g.Expect(err).To(MatchError(tt.err))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			tt.assert(g, tcp)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/metrics"
+)
+
+// normalizeVersion tolerates version strings without a "v" prefix, as expected by Kamaji.
+func normalizeVersion(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+
+	return version
+}
+
+// reconcileUpgradeStatus tracks the Kubernetes version upgrade of the TenantControlPlane through its phases,
+// reporting the previous version as the minimum one running in the control plane until every replica has been
+// updated and is available: Cluster API relies on this value to hold the MachineDeployment rollouts.
+// It returns true when the upgrade has just been completed or failed.
+func reconcileUpgradeStatus(kcp *kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, conditions *[]metav1.Condition) bool {
+	desiredVersion := normalizeVersion(kcp.Spec.Version)
+
+	var cancelled bool
+
+	switch {
+	case kcp.Status.Version == "":
+		// An empty version means the Tenant Control Plane is being provisioned, rather than upgraded.
+	case kcp.Status.Version != desiredVersion && (kcp.Status.Upgrade == nil || kcp.Status.Upgrade.TargetVersion != desiredVersion):
+		kcp.Status.Upgrade = &kcpv1alpha2.KamajiControlPlaneUpgradeStatus{
+			Phase:           kcpv1alpha2.UpgradePhasePending,
+			PreviousVersion: kcp.Status.Version,
+			TargetVersion:   desiredVersion,
+			StartTime:       ptr.To(metav1.Now()),
+		}
+	case kcp.Status.Upgrade.IsInProgress() && kcp.Status.Upgrade.TargetVersion != desiredVersion:
+		// The version has been reverted to the one running in the control plane, abandoning the upgrade.
+		kcp.Status.Upgrade, cancelled = nil, true
+	}
+
+	upgrade := kcp.Status.Upgrade
+
+	if !upgrade.IsInProgress() {
+		kcp.Status.Version = tcp.Status.Kubernetes.Version.Version
+
+		reason := "NoUpgrade"
+
+		switch {
+		case cancelled:
+			reason = "UpgradeCancelled"
+		case upgrade != nil:
+			reason = "UpgradeCompleted"
+		}
+
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.UpgradeInProgressConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: kcp.Generation,
+		})
+
+		return false
+	}
+
+	upgrade.Phase = upgradePhase(kcp, tcp, upgrade.TargetVersion)
+
+	if upgrade.Phase != kcpv1alpha2.UpgradePhaseDone && upgradeDeadlineExceeded(kcp) {
+		upgrade.Phase = kcpv1alpha2.UpgradePhaseFailed
+		kcp.Status.Version = upgrade.PreviousVersion
+
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.UpgradeInProgressConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             "UpgradeFailed",
+			Message:            fmt.Sprintf("upgrade from %s to %s exceeded the progress deadline of %s", upgrade.PreviousVersion, upgrade.TargetVersion, kcp.Spec.UpgradePolicy.ProgressDeadline.Duration),
+			ObservedGeneration: kcp.Generation,
+		})
+
+		return true
+	}
+
+	if upgrade.Phase == kcpv1alpha2.UpgradePhaseDone {
+		upgrade.CompletionTime = ptr.To(metav1.Now())
+		kcp.Status.Version = upgrade.TargetVersion
+
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(kcpv1alpha2.UpgradeInProgressConditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             "UpgradeCompleted",
+			Message:            fmt.Sprintf("upgraded from %s to %s", upgrade.PreviousVersion, upgrade.TargetVersion),
+			ObservedGeneration: kcp.Generation,
+		})
+
+		return true
+	}
+
+	kcp.Status.Version = upgrade.PreviousVersion
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               string(kcpv1alpha2.UpgradeInProgressConditionType),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(upgrade.Phase),
+		Message:            fmt.Sprintf("upgrading from %s to %s", upgrade.PreviousVersion, upgrade.TargetVersion),
+		ObservedGeneration: kcp.Generation,
+	})
+
+	return false
+}
+
+// observeUpgradeDuration records the duration of the completed or failed upgrade:
+// it must be called once the status reporting the upgrade outcome has been persisted.
+func observeUpgradeDuration(upgrade *kcpv1alpha2.KamajiControlPlaneUpgradeStatus) {
+	switch {
+	case upgrade == nil || upgrade.StartTime == nil:
+		return
+	case upgrade.IsFailed():
+		metrics.UpgradeDuration.WithLabelValues("failed").Observe(time.Since(upgrade.StartTime.Time).Seconds())
+	case upgrade.CompletionTime != nil:
+		metrics.UpgradeDuration.WithLabelValues("completed").Observe(upgrade.CompletionTime.Sub(upgrade.StartTime.Time).Seconds())
+	}
+}
+
+// upgradeDeadline returns the time the in-progress upgrade must be completed by, if an upgrade policy is set.
+func upgradeDeadline(kcp *kcpv1alpha2.KamajiControlPlane) (time.Time, bool) {
+	upgrade := kcp.Status.Upgrade
+
+	if kcp.Spec.UpgradePolicy == nil || !upgrade.IsInProgress() || upgrade.StartTime == nil {
+		return time.Time{}, false
+	}
+
+	return upgrade.StartTime.Add(kcp.Spec.UpgradePolicy.ProgressDeadline.Duration), true
+}
+
+func upgradeDeadlineExceeded(kcp *kcpv1alpha2.KamajiControlPlane) bool {
+	deadline, ok := upgradeDeadline(kcp)
+
+	return ok && time.Now().After(deadline)
+}
+
+// upgradePhase computes the upgrade phase from the TenantControlPlane status:
+// the upgrade is considered done only once every replica is updated and available, with no old replicas left.
+func upgradePhase(kcp *kcpv1alpha2.KamajiControlPlane, tcp *kamajiv1alpha1.TenantControlPlane, targetVersion string) kcpv1alpha2.KamajiControlPlaneUpgradePhase {
+	versionStatus, deployment := tcp.Status.Kubernetes.Version, tcp.Status.Kubernetes.Deployment
+	desiredReplicas := ptr.Deref(kcp.Spec.Replicas, deployment.Replicas)
+
+	switch {
+	case versionStatus.Status != nil && *versionStatus.Status == kamajiv1alpha1.VersionUpgrading:
+		return kcpv1alpha2.UpgradePhaseRolling
+	case versionStatus.Version != targetVersion:
+		return kcpv1alpha2.UpgradePhasePending
+	case deployment.UpdatedReplicas < desiredReplicas:
+		return kcpv1alpha2.UpgradePhaseRolling
+	case deployment.AvailableReplicas < desiredReplicas || deployment.Replicas > deployment.UpdatedReplicas:
+		return kcpv1alpha2.UpgradePhaseVerifying
+	default:
+		return kcpv1alpha2.UpgradePhaseDone
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	. "github.com/onsi/gomega" //nolint:revive
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+//nolint:funlen
+func TestReconcileUpgradeStatus(t *testing.T) {
+	// tenantControlPlane returns a TenantControlPlane running the given version with every replica updated and available.
+	tenantControlPlane := func(version string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		tcp.Status.Kubernetes.Version.Version = version
+		tcp.Status.Kubernetes.Version.Status = ptr.To(kamajiv1alpha1.VersionReady)
+		tcp.Status.Kubernetes.Deployment.Replicas = 2
+		tcp.Status.Kubernetes.Deployment.UpdatedReplicas = 2
+		tcp.Status.Kubernetes.Deployment.AvailableReplicas = 2
+
+		return tcp
+	}
+	upgrading := func(previous, target string, startTime time.Time) *kcpv1alpha2.KamajiControlPlaneUpgradeStatus {
+		return &kcpv1alpha2.KamajiControlPlaneUpgradeStatus{
+			Phase:           kcpv1alpha2.UpgradePhasePending,
+			PreviousVersion: previous,
+			TargetVersion:   target,
+			StartTime:       ptr.To(metav1.NewTime(startTime)),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		specVersion   string
+		statusVersion string
+		upgrade       *kcpv1alpha2.KamajiControlPlaneUpgradeStatus
+		upgradePolicy *kcpv1alpha2.UpgradePolicy
+		tcp           *kamajiv1alpha1.TenantControlPlane
+		done          bool
+		expected      *kcpv1alpha2.KamajiControlPlaneUpgradeStatus
+		version       string
+		reason        string
+	}{
+		{
+			name:        "provisioning",
+			specVersion: "v1.33.0",
+			tcp:         tenantControlPlane("v1.33.0"),
+			version:     "v1.33.0",
+			reason:      "NoUpgrade",
+		},
+		{
+			name:          "upgrade requested",
+			specVersion:   "v1.34.0",
+			statusVersion: "v1.33.0",
+			tcp:           tenantControlPlane("v1.33.0"),
+			expected:      &kcpv1alpha2.KamajiControlPlaneUpgradeStatus{Phase: kcpv1alpha2.UpgradePhasePending, PreviousVersion: "v1.33.0", TargetVersion: "v1.34.0"},
+			version:       "v1.33.0",
+			reason:        string(kcpv1alpha2.UpgradePhasePending),
+		},
+		{
+			name:          "upgrade completed",
+			specVersion:   "v1.34.0",
+			statusVersion: "v1.33.0",
+			upgrade:       upgrading("v1.33.0", "v1.34.0", time.Now()),
+			tcp:           tenantControlPlane("v1.34.0"),
+			done:          true,
+			expected:      &kcpv1alpha2.KamajiControlPlaneUpgradeStatus{Phase: kcpv1alpha2.UpgradePhaseDone, PreviousVersion: "v1.33.0", TargetVersion: "v1.34.0"},
+			version:       "v1.34.0",
+			reason:        "UpgradeCompleted",
+		},
+		{
+			name:          "upgrade reverted",
+			specVersion:   "v1.33.0",
+			statusVersion: "v1.33.0",
+			upgrade:       upgrading("v1.33.0", "v1.34.0", time.Now()),
+			tcp:           tenantControlPlane("v1.33.0"),
+			version:       "v1.33.0",
+			reason:        "UpgradeCancelled",
+		},
+		{
+			name:          "upgrade reverted past the deadline",
+			specVersion:   "v1.33.0",
+			statusVersion: "v1.33.0",
+			upgrade:       upgrading("v1.33.0", "v1.34.0", time.Now().Add(-time.Hour)),
+			upgradePolicy: &kcpv1alpha2.UpgradePolicy{ProgressDeadline: metav1.Duration{Duration: time.Minute}},
+			tcp:           tenantControlPlane("v1.33.0"),
+			version:       "v1.33.0",
+			reason:        "UpgradeCancelled",
+		},
+		{
+			name:          "upgrade retargeted",
+			specVersion:   "v1.34.1",
+			statusVersion: "v1.33.0",
+			upgrade:       upgrading("v1.33.0", "v1.34.0", time.Now()),
+			tcp:           tenantControlPlane("v1.33.0"),
+			expected:      &kcpv1alpha2.KamajiControlPlaneUpgradeStatus{Phase: kcpv1alpha2.UpgradePhasePending, PreviousVersion: "v1.33.0", TargetVersion: "v1.34.1"},
+			version:       "v1.33.0",
+			reason:        string(kcpv1alpha2.UpgradePhasePending),
+		},
+		{
+			name:          "upgrade past the deadline",
+			specVersion:   "v1.34.0",
+			statusVersion: "v1.33.0",
+			upgrade:       upgrading("v1.33.0", "v1.34.0", time.Now().Add(-time.Hour)),
+			upgradePolicy: &kcpv1alpha2.UpgradePolicy{ProgressDeadline: metav1.Duration{Duration: time.Minute}},
+			tcp:           tenantControlPlane("v1.33.0"),
+			done:          true,
+			expected:      &kcpv1alpha2.KamajiControlPlaneUpgradeStatus{Phase: kcpv1alpha2.UpgradePhaseFailed, PreviousVersion: "v1.33.0", TargetVersion: "v1.34.0"},
+			version:       "v1.33.0",
+			reason:        "UpgradeFailed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &kcpv1alpha2.KamajiControlPlane{}
+			kcp.Spec.Version = tt.specVersion
+			kcp.Spec.Replicas = ptr.To(int32(2))
+			kcp.Spec.UpgradePolicy = tt.upgradePolicy
+			kcp.Status.Version = tt.statusVersion
+			kcp.Status.Upgrade = tt.upgrade
+
+			var conditions []metav1.Condition
+
+			g.Expect(reconcileUpgradeStatus(kcp, tt.tcp, &conditions)).To(Equal(tt.done))
+			g.Expect(kcp.Status.Version).To(Equal(tt.version))
+
+			if tt.expected == nil {
+				g.Expect(kcp.Status.Upgrade).To(BeNil())
+			} else {
+				g.Expect(kcp.Status.Upgrade).NotTo(BeNil())
+				g.Expect(kcp.Status.Upgrade.Phase).To(Equal(tt.expected.Phase))
+				g.Expect(kcp.Status.Upgrade.PreviousVersion).To(Equal(tt.expected.PreviousVersion))
+				g.Expect(kcp.Status.Upgrade.TargetVersion).To(Equal(tt.expected.TargetVersion))
+			}
+
+			condition := meta.FindStatusCondition(conditions, string(kcpv1alpha2.UpgradeInProgressConditionType))
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Reason).To(Equal(tt.reason))
+		})
+	}
+}
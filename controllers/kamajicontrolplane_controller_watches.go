@@ -0,0 +1,181 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/indexers"
+)
+
+// waitBackoff computes the delay of the requeues waiting for a condition not yet satisfied: it grows exponentially
+// per object, up to the configured cap, only when the previous requeue is due. The reconciliations triggered earlier
+// by the watches are just checking again the condition, keeping the pending requeue.
+type waitBackoff struct {
+	limiter workqueue.TypedRateLimiter[ctrl.Request]
+	clock   clock.PassiveClock
+
+	lock sync.Mutex
+	// due is the time the pending requeue of each object is due at.
+	due map[ctrl.Request]time.Time
+	// waiting tracks the objects whose ongoing reconciliation is waiting for a condition.
+	waiting map[ctrl.Request]bool
+}
+
+func newWaitBackoff(baseDelay, maxDelay time.Duration, clock clock.PassiveClock) *waitBackoff {
+	return &waitBackoff{
+		limiter: workqueue.NewTypedItemExponentialFailureRateLimiter[ctrl.Request](baseDelay, maxDelay),
+		clock:   clock,
+		due:     make(map[ctrl.Request]time.Time),
+		waiting: make(map[ctrl.Request]bool),
+	}
+}
+
+// start must be called before reconciling the object.
+func (b *waitBackoff) start(req ctrl.Request) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.waiting, req)
+}
+
+// when returns the delay before checking again the condition the object is waiting for.
+func (b *waitBackoff) when(req ctrl.Request) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.waiting[req] = true
+
+	now := b.clock.Now()
+	// Triggered by a watch before the requeue is due: the delay is not increased.
+	if due, ok := b.due[req]; ok && now.Before(due) {
+		return due.Sub(now)
+	}
+
+	delay := b.limiter.When(req)
+	b.due[req] = now.Add(delay)
+
+	return delay
+}
+
+// finish must be called once the object has been reconciled: the delay is reset unless waiting for a condition,
+// such as when the reconciliation is completed, paused, or failed.
+func (b *waitBackoff) finish(req ctrl.Request) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.waiting[req] {
+		return
+	}
+
+	b.limiter.Forget(req)
+	delete(b.due, req)
+}
+
+// requeueWithBackoff returns the Result to wait for a condition not yet satisfied.
+// Watches are the main trigger, the requeue is just a safety net for missed events.
+func (r *KamajiControlPlaneReconciler) requeueWithBackoff(req ctrl.Request) ctrl.Result {
+	return ctrl.Result{RequeueAfter: r.waitBackoff.when(req)}
+}
+
+// clusterToKamajiControlPlanes maps a Cluster to the owned KamajiControlPlane instances.
+func (r *KamajiControlPlaneReconciler) clusterToKamajiControlPlanes(ctx context.Context, object client.Object) []ctrl.Request {
+	var kcpList kcpv1alpha2.KamajiControlPlaneList
+
+	if err := r.client.List(ctx, &kcpList, client.InNamespace(object.GetNamespace()), client.MatchingFields{indexers.KamajiControlPlaneClusterField: object.GetName()}); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "unable to list KamajiControlPlane owned by the Cluster", "cluster", object.GetName())
+
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(kcpList.Items))
+
+	for _, kcp := range kcpList.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: kcp.Namespace, Name: kcp.Name}})
+	}
+
+	return requests
+}
+
+// clusterChanged filters the Cluster updates that must be propagated to the TenantControlPlane, such as the
+// cluster network and the pause, or the KamajiControlPlane reconciliation could be waiting for, such as the
+// Control Plane endpoint assignment and the infrastructure provisioning.
+func clusterChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, oldOk := e.ObjectOld.(*capiv1beta2.Cluster)
+			newCluster, newOk := e.ObjectNew.(*capiv1beta2.Cluster)
+
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldCluster.Spec.ClusterNetwork, newCluster.Spec.ClusterNetwork) ||
+				ptr.Deref(oldCluster.Spec.Paused, false) != ptr.Deref(newCluster.Spec.Paused, false) ||
+				oldCluster.Spec.ControlPlaneEndpoint != newCluster.Spec.ControlPlaneEndpoint ||
+				ptr.Deref(oldCluster.Status.Initialization.InfrastructureProvisioned, false) != ptr.Deref(newCluster.Status.Initialization.InfrastructureProvisioned, false)
+		},
+		CreateFunc: func(event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// horizontalPodAutoscalerChanged filters the HorizontalPodAutoscaler updates reporting a scaling decision,
+// ignoring the ones just refreshing the current metrics.
+func horizontalPodAutoscalerChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldHPA, oldOk := e.ObjectOld.(*autoscalingv2.HorizontalPodAutoscaler)
+			newHPA, newOk := e.ObjectNew.(*autoscalingv2.HorizontalPodAutoscaler)
+
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldHPA.Spec, newHPA.Spec) ||
+				oldHPA.Status.CurrentReplicas != newHPA.Status.CurrentReplicas ||
+				oldHPA.Status.DesiredReplicas != newHPA.Status.DesiredReplicas ||
+				!equality.Semantic.DeepEqual(oldHPA.Status.LastScaleTime, newHPA.Status.LastScaleTime) ||
+				!equality.Semantic.DeepEqual(oldHPA.Status.Conditions, newHPA.Status.Conditions)
+		},
+	}
+}
+
+// tenantControlPlaneSecretToOwner maps the Secrets generated by Kamaji, such as the kubeconfig and
+// the Certificate Authority, to the owning TenantControlPlane name: in the management cluster this matches the
+// KamajiControlPlane one, in the external cluster reference one it's processed by the PushKamajiChange controller.
+func tenantControlPlaneSecretToOwner(_ context.Context, object client.Object) []ctrl.Request {
+	for _, ownerRef := range object.GetOwnerReferences() {
+		if ownerRef.Kind != "TenantControlPlane" || !ptr.Deref(ownerRef.Controller, false) {
+			continue
+		}
+
+		return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: ownerRef.Name}}}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestWaitBackoff(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	backoff := newWaitBackoff(time.Second, time.Minute, fakeClock)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "tenant"}}
+
+	reconcile := func(wait bool) time.Duration {
+		backoff.start(req)
+		defer backoff.finish(req)
+
+		if !wait {
+			return 0
+		}
+
+		return backoff.when(req)
+	}
+
+	g.Expect(reconcile(true)).To(Equal(time.Second))
+	// Triggered by a watch before the requeue is due.
+	fakeClock.SetTime(fakeClock.Now().Add(400 * time.Millisecond))
+	g.Expect(reconcile(true)).To(Equal(600 * time.Millisecond))
+	// Triggered by the requeue.
+	fakeClock.SetTime(fakeClock.Now().Add(600 * time.Millisecond))
+	g.Expect(reconcile(true)).To(Equal(2 * time.Second))
+
+	fakeClock.SetTime(fakeClock.Now().Add(2 * time.Second))
+	g.Expect(reconcile(true)).To(Equal(4 * time.Second))
+	// Not waiting, such as when paused or failed, the delay is reset.
+	g.Expect(reconcile(false)).To(BeZero())
+	g.Expect(reconcile(true)).To(Equal(time.Second))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -67,11 +68,17 @@ func (r *KamajiControlPlaneReconciler) handleDeletion(ctx context.Context, kcp v
 
 		log.Error(tcpErr, "cannot delete remote TenantControlPlane")
 
+		r.recorder.Eventf(&kcp, nil, corev1.EventTypeWarning, EventReasonRemoteTenantControlPlaneDeletionFailed, EventActionDelete,
+			"cannot delete remote TenantControlPlane %s/%s: %s", tcp.Namespace, tcp.Name, tcpErr.Error())
+
 		return tcpErr //nolint:wrapcheck
 	}
 
 	log.Info("remote TenantControlPlane has been deleted")
 
+	r.recorder.Eventf(&kcp, nil, corev1.EventTypeNormal, EventReasonRemoteTenantControlPlaneDeleted, EventActionDelete,
+		"remote TenantControlPlane %s/%s has been deleted", tcp.Namespace, tcp.Name)
+
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		if err := r.client.Get(ctx, types.NamespacedName{Name: kcp.Name, Namespace: kcp.Namespace}, &kcp); err != nil {
 			return err //nolint:wrapcheck
@@ -4,13 +4,18 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -18,9 +23,12 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/component-base/featuregate"
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util/flags"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -31,6 +39,7 @@ import (
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/externalclusterreference"
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/features"
 	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/indexers"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/tracing"
 )
 
 var (
@@ -48,21 +57,45 @@ func init() {
 
 //nolint:funlen,cyclop
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == planCommand {
+		if err := runPlan(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	var dynamicInfraClusters []string
 
+	var infraPatchStrategies string
+
 	metricsAddr, enableLeaderElection, probeAddr, maxConcurrentReconciles, managerOpts := "", false, "", 1, flags.ManagerOptions{}
 
+	var requeueBaseDelay, requeueMaxDelay time.Duration
+
+	var tracingOpts tracing.Options
+
 	flagSet := pflag.CommandLine
 
 	flagSet.StringSliceVar(&dynamicInfraClusters, "dynamic-infrastructure-clusters", nil, "When the DynamicInfrastructureClusterPatch feature flag is enabled, "+
 		"allows specifying which Infrastructure Clusters can be dynamically patched. "+
 		"This feature is useful for developers of custom or non public Cluster API infrastructure providers.")
+	flagSet.StringVar(&infraPatchStrategies, "infrastructure-patch-strategies", "", "When the DynamicInfrastructureClusterPatch feature flag is enabled, "+
+		"the ConfigMap declaring the Infrastructure Clusters patch strategies, in the form of namespace/name: "+
+		"each key is an Infrastructure Cluster kind, and the value its strategy with the action, the host and port field paths, and the status fields to set.")
 	flagSet.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flagSet.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flagSet.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flagSet.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1, "The maximum number of concurrent KamajiControlPlane reconciles which can be run")
+	flagSet.DurationVar(&requeueBaseDelay, "requeue-base-delay", time.Second, "The initial delay before checking again a KamajiControlPlane waiting for a condition, doubled upon each attempt.")
+	flagSet.DurationVar(&requeueMaxDelay, "requeue-max-delay", 5*time.Minute, "The maximum delay before checking again a KamajiControlPlane waiting for a condition.") //nolint:mnd
+	flagSet.StringVar(&tracingOpts.Exporter, "tracing-exporter", tracing.ExporterNone, "The OpenTelemetry tracing exporter, one of none, otlp, or stdout.")
+	flagSet.StringVar(&tracingOpts.Endpoint, "tracing-otlp-endpoint", "localhost:4317", "The OTLP gRPC collector endpoint the traces are sent to, in the form of host:port.")
+	flagSet.BoolVar(&tracingOpts.Insecure, "tracing-otlp-insecure", false, "Disable the TLS transport towards the OTLP collector.")
+	flagSet.Float64Var(&tracingOpts.SamplingRatio, "tracing-sampling-ratio", 1, "The fraction of the KamajiControlPlane reconciliations to be traced, between 0 and 1.")
 	// zap logging FlagSet
 	var goFlagSet flag.FlagSet
 
@@ -120,8 +153,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	var infraPatchStrategiesRef types.NamespacedName
+
+	if infraPatchStrategies != "" {
+		if !featureGate.Enabled(features.DynamicInfrastructureClusterPatch) {
+			setupLog.Error(errors.New("cannot set infrastructure patch strategies when the feature flag is disabled"), "DynamicInfrastructureClusterPatch feature flag is disabled")
+			os.Exit(1)
+		}
+
+		namespace, name, found := strings.Cut(infraPatchStrategies, "/")
+		if !found || namespace == "" || name == "" {
+			setupLog.Error(errors.Errorf("invalid ConfigMap reference %q", infraPatchStrategies), "infrastructure patch strategies must be in the form of namespace/name")
+			os.Exit(1)
+		}
+
+		infraPatchStrategiesRef = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:  scheme,
 		Metrics: *metricsOpts,
@@ -143,16 +199,43 @@ func main() {
 	}
 
 	ecrStore, triggerChannel := externalclusterreference.NewStore(), make(chan event.GenericEvent)
+	// Sharing a single connection per workload cluster, rather than creating a client at each reconciliation.
+	clusterCache, err := clustercache.SetupWithManager(ctx, mgr, clustercache.Options{
+		SecretClient: mgr.GetClient(),
+		Client: clustercache.ClientOptions{
+			UserAgent: remote.DefaultClusterAPIUserAgent("kamaji-control-plane-provider"),
+		},
+	}, controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles})
+	if err != nil {
+		setupLog.Error(err, "unable to create cluster cache")
+		os.Exit(1)
+	}
 
 	if err = (&controllers.KamajiControlPlaneReconciler{
 		ExternalClusterReferenceStore: ecrStore,
 		FeatureGates:                  featureGate,
 		MaxConcurrentReconciles:       maxConcurrentReconciles,
+		RequeueBaseDelay:              requeueBaseDelay,
+		RequeueMaxDelay:               requeueMaxDelay,
 		DynamicInfrastructureClusters: sets.New[string](dynamicInfraClusters...),
+		InfrastructurePatchStrategies: infraPatchStrategiesRef,
+		ClusterCache:                  clusterCache,
 	}).SetupWithManager(ctx, mgr, triggerChannel); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KamajiControlPlane")
 		os.Exit(1)
 	}
+
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&controlplanev1alpha2.KamajiControlPlaneWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KamajiControlPlane")
+			os.Exit(1)
+		}
+
+		if err = (&controlplanev1alpha2.KamajiControlPlaneTemplateWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KamajiControlPlaneTemplate")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if featureGate.Enabled(features.ExternalClusterReference) || featureGate.Enabled(features.ExternalClusterReferenceCrossNamespace) {
@@ -161,7 +244,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = (&controllers.ExternalClusterReferenceReconciler{Client: mgr.GetClient(), Store: ecrStore, TriggerChannel: triggerChannel}).SetupWithManager(mgr); err != nil {
+		if err = (&controllers.ExternalClusterReferenceReconciler{Client: mgr.GetClient(), Recorder: mgr.GetEventRecorder("external-cluster-reference-controller"), Store: ecrStore, TriggerChannel: triggerChannel}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ExternalClusterReference")
 			os.Exit(1)
 		}
@@ -179,7 +262,13 @@ func main() {
 
 	setupLog.Info("starting manager")
 
-	if err = mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	// Flushing the pending spans before exiting, the signal handler context is already cancelled.
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "unable to flush traces")
+	}
+
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -20,6 +20,7 @@ type Store interface {
 	Get(name, rv string) (ctrl.Manager, bool)
 	Stop(name string) bool
 	Add(name, rv string, manager ctrl.Manager, cancelFn context.CancelFunc) bool
+	Len() int
 }
 
 type mapStore struct {
@@ -79,3 +80,10 @@ func (m *mapStore) Add(name, resourceVersion string, manager ctrl.Manager, cance
 
 	return true
 }
+
+func (m *mapStore) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.store)
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package indexers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const (
+	KamajiControlPlaneAddonBundleField = "kamajiControlPlaneAddonBundle"
+)
+
+// KamajiControlPlaneAddonBundle indexes the KamajiControlPlane by the ConfigMaps and Secrets referenced as addon bundle,
+// in the form of kind/name.
+type KamajiControlPlaneAddonBundle struct{}
+
+func (k KamajiControlPlaneAddonBundle) Object() client.Object { //nolint:ireturn
+	return &kcpv1alpha2.KamajiControlPlane{}
+}
+
+func (k KamajiControlPlaneAddonBundle) Field() string {
+	return KamajiControlPlaneAddonBundleField
+}
+
+func (k KamajiControlPlaneAddonBundle) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		kcp := object.(*kcpv1alpha2.KamajiControlPlane) //nolint:forcetypeassert
+
+		values := make([]string, 0, len(kcp.Spec.AddonBundles))
+
+		for _, bundle := range kcp.Spec.AddonBundles {
+			values = append(values, bundle.Kind+"/"+bundle.ResourceName)
+		}
+
+		return values
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package indexers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcpv1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const (
+	KamajiControlPlaneClusterField = "kamajiControlPlaneCluster"
+)
+
+// KamajiControlPlaneCluster indexes the KamajiControlPlane by the name of the owning Cluster.
+type KamajiControlPlaneCluster struct{}
+
+func (k KamajiControlPlaneCluster) Object() client.Object { //nolint:ireturn
+	return &kcpv1alpha2.KamajiControlPlane{}
+}
+
+func (k KamajiControlPlaneCluster) Field() string {
+	return KamajiControlPlaneClusterField
+}
+
+func (k KamajiControlPlaneCluster) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		kcp := object.(*kcpv1alpha2.KamajiControlPlane) //nolint:forcetypeassert
+
+		for _, ownerRef := range kcp.GetOwnerReferences() {
+			gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+			if err != nil {
+				continue
+			}
+
+			if gv.Group == capiv1beta2.GroupVersion.Group && ownerRef.Kind == "Cluster" {
+				return []string{ownerRef.Name}
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+// Action is the behaviour of a patch strategy towards the InfraCluster control plane endpoint.
+type Action string
+
+const (
+	// ActionPatch sets the control plane endpoint on the InfraCluster.
+	ActionPatch Action = "Patch"
+	// ActionCheck ensures the control plane endpoint declared on the InfraCluster matches the Kamaji one.
+	ActionCheck Action = "Check"
+	// ActionCheckOrPatch sets the control plane endpoint on the InfraCluster only when it's not declared,
+	// ensuring it matches the Kamaji one otherwise.
+	ActionCheckOrPatch Action = "CheckOrPatch"
+)
+
+const (
+	// DefaultHostField is the InfraCluster field path of the control plane endpoint host defined by the Cluster API contract.
+	DefaultHostField = "spec.controlPlaneEndpoint.host"
+	// DefaultPortField is the InfraCluster field path of the control plane endpoint port defined by the Cluster API contract.
+	DefaultPortField = "spec.controlPlaneEndpoint.port"
+)
+
+// Strategy describes how the control plane endpoint is reconciled on an InfraCluster kind.
+type Strategy struct {
+	// Action is the behaviour towards the InfraCluster, defaults to Patch.
+	Action Action `json:"action,omitempty"`
+	// HostField is the dot separated field path of the control plane endpoint host.
+	HostField string `json:"hostField,omitempty"`
+	// PortField is the dot separated field path of the control plane endpoint port.
+	PortField string `json:"portField,omitempty"`
+	// StatusFields are the status fields set upon patching, keyed by their dot separated field path:
+	// required by the providers not managing the InfraCluster readiness, such as KubeVirt.
+	StatusFields map[string]interface{} `json:"statusFields,omitempty"`
+}
+
+const (
+	// ContractV1Beta1 is the Cluster API contract where the InfraCluster readiness is reported with status.ready.
+	ContractV1Beta1 = "v1beta1"
+	// ContractV1Beta2 is the Cluster API contract where the InfraCluster readiness is reported with status.initialization.provisioned.
+	ContractV1Beta2 = "v1beta2"
+)
+
+// v1beta2StatusFields maps the v1beta1 contract status fields to the v1beta2 ones.
+var v1beta2StatusFields = map[string]string{
+	"status.ready": "status.initialization.provisioned",
+}
+
+// Registry maps the InfraCluster kinds to their patch strategy.
+type Registry map[string]Strategy
+
+var readyStatusFields = map[string]interface{}{"status.ready": true}
+
+// DefaultRegistry returns the patch strategies of the supported Cluster API infrastructure providers.
+func DefaultRegistry() Registry {
+	return Registry{
+		"AWSCluster":        {Action: ActionPatch},
+		"AzureCluster":      {Action: ActionPatch},
+		"HetznerCluster":    {Action: ActionPatch},
+		"IonosCloudCluster": {Action: ActionPatch},
+		"KubevirtCluster":   {Action: ActionPatch, StatusFields: readyStatusFields},
+		"Metal3Cluster":     {Action: ActionCheck},
+		"MetalStackCluster": {Action: ActionCheck},
+		"NutanixCluster":    {Action: ActionPatch, StatusFields: readyStatusFields},
+		"OpenStackCluster":  {Action: ActionPatch, HostField: "spec.apiServerFixedIP", PortField: "spec.apiServerPort"},
+		"PacketCluster":     {Action: ActionPatch, StatusFields: readyStatusFields},
+		"ProxmoxCluster":    {Action: ActionCheckOrPatch},
+		"TinkerbellCluster": {Action: ActionCheckOrPatch},
+		"VSphereCluster":    {Action: ActionCheckOrPatch},
+	}
+}
+
+// ParseConfigMap decodes the patch strategies declared in a ConfigMap,
+// where each key is an InfraCluster kind and the value its YAML or JSON strategy.
+func ParseConfigMap(configMap corev1.ConfigMap) (Registry, error) {
+	registry := Registry{}
+
+	for kind, data := range configMap.Data {
+		var strategy Strategy
+		if err := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(data), len(data)).Decode(&strategy); err != nil {
+			return nil, errors.Wrapf(err, "cannot decode the %s patch strategy", kind)
+		}
+
+		if err := strategy.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s patch strategy", kind)
+		}
+
+		registry[kind] = strategy
+	}
+
+	return registry, nil
+}
+
+// ContractVersion returns the latest Cluster API contract declared with the cluster.x-k8s.io/<version> labels
+// on the InfraCluster CustomResourceDefinition, defaulting to v1beta1 when the v1beta2 one is not declared.
+func ContractVersion(crd metav1.Object) string {
+	if crd.GetLabels()[capiv1beta2.GroupVersion.Group+"/"+ContractV1Beta2] != "" {
+		return ContractV1Beta2
+	}
+
+	return ContractV1Beta1
+}
+
+// StrategyFromAnnotations decodes the patch strategy declared with the well-known annotations
+// on an InfraCluster CustomResourceDefinition, returning false when none is set.
+func StrategyFromAnnotations(annotations map[string]string) (Strategy, bool, error) {
+	action, hasAction := annotations[v1alpha2.InfrastructurePatchActionAnnotation]
+	hostField, hasHostField := annotations[v1alpha2.InfrastructureHostFieldAnnotation]
+	portField, hasPortField := annotations[v1alpha2.InfrastructurePortFieldAnnotation]
+	readyField, hasReadyField := annotations[v1alpha2.InfrastructureReadyFieldAnnotation]
+
+	if !hasAction && !hasHostField && !hasPortField && !hasReadyField {
+		return Strategy{}, false, nil
+	}
+
+	strategy := Strategy{Action: Action(action), HostField: hostField, PortField: portField}
+	if readyField != "" {
+		strategy.StatusFields = map[string]interface{}{readyField: true}
+	}
+
+	if err := strategy.Validate(); err != nil {
+		return Strategy{}, false, err
+	}
+
+	return strategy, true, nil
+}
+
+// Resolve returns the patch strategy of an InfraCluster kind, in order of precedence: the declared ones, such as with
+// a ConfigMap, the one set with the well-known annotations on the InfraCluster CustomResourceDefinition, the built-in
+// ones, and the plain patch of the dynamic kinds. The status fields are translated according to the contract declared
+// on the CustomResourceDefinition, returning false when no strategy is known for the kind.
+func Resolve(kind string, declared Registry, crd metav1.Object, dynamic bool) (Strategy, bool, error) {
+	contractVersion := ContractVersion(crd)
+
+	if strategy, ok := declared[kind]; ok {
+		return strategy.ForContract(contractVersion), true, nil
+	}
+
+	strategy, ok, err := StrategyFromAnnotations(crd.GetAnnotations())
+	if err != nil {
+		return Strategy{}, false, errors.Wrapf(err, "invalid patch strategy declared on the %s CustomResourceDefinition", crd.GetName())
+	}
+
+	if ok {
+		return strategy.ForContract(contractVersion), true, nil
+	}
+
+	if strategy, ok = DefaultRegistry()[kind]; ok {
+		return strategy.ForContract(contractVersion), true, nil
+	}
+
+	if dynamic {
+		return Strategy{Action: ActionPatch}, true, nil
+	}
+
+	return Strategy{}, false, nil
+}
+
+// Validate ensures the strategy action is known, and the status fields are targeting the status.
+func (s Strategy) Validate() error {
+	switch s.Action {
+	case "", ActionPatch, ActionCheck, ActionCheckOrPatch:
+	default:
+		return fmt.Errorf("unknown action %q, must be one of %s, %s, or %s", s.Action, ActionPatch, ActionCheck, ActionCheckOrPatch)
+	}
+
+	for field := range s.StatusFields {
+		if !strings.HasPrefix(field, "status.") {
+			return fmt.Errorf("the status field %q must be prefixed with status", field)
+		}
+	}
+
+	return nil
+}
+
+// ForContract returns the strategy with the status fields translated to the given contract ones:
+// the strategies are declared with the v1beta1 contract fields, such as status.ready.
+func (s Strategy) ForContract(contractVersion string) Strategy {
+	if contractVersion != ContractV1Beta2 || len(s.StatusFields) == 0 {
+		return s
+	}
+
+	fields := make(map[string]interface{}, len(s.StatusFields))
+
+	for field, value := range s.StatusFields {
+		if translated, ok := v1beta2StatusFields[field]; ok {
+			field = translated
+		}
+
+		fields[field] = value
+	}
+
+	s.StatusFields = fields
+
+	return s
+}
+
+// GetAction returns the strategy action, defaulting to Patch.
+func (s Strategy) GetAction() Action {
+	if s.Action == "" {
+		return ActionPatch
+	}
+
+	return s.Action
+}
+
+func (s Strategy) hostField() []string {
+	if s.HostField == "" {
+		return strings.Split(DefaultHostField, ".")
+	}
+
+	return strings.Split(s.HostField, ".")
+}
+
+func (s Strategy) portField() []string {
+	if s.PortField == "" {
+		return strings.Split(DefaultPortField, ".")
+	}
+
+	return strings.Split(s.PortField, ".")
+}
+
+// Endpoint returns the control plane endpoint declared on the InfraCluster.
+func (s Strategy) Endpoint(infraCluster *unstructured.Unstructured) (string, int64, error) {
+	host, _, err := unstructured.NestedString(infraCluster.Object, s.hostField()...)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "cannot extract control plane endpoint host")
+	}
+
+	port, _, err := unstructured.NestedInt64(infraCluster.Object, s.portField()...)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "cannot extract control plane endpoint port")
+	}
+
+	return host, port, nil
+}
+
+// SetEndpoint sets the control plane endpoint and the status fields on the InfraCluster.
+func (s Strategy) SetEndpoint(infraCluster *unstructured.Unstructured, host string, port int64) error {
+	if err := unstructured.SetNestedField(infraCluster.Object, host, s.hostField()...); err != nil {
+		return errors.Wrapf(err, "unable to set unstructured %s %s", infraCluster.GetKind(), strings.Join(s.hostField(), "."))
+	}
+
+	if err := unstructured.SetNestedField(infraCluster.Object, port, s.portField()...); err != nil {
+		return errors.Wrapf(err, "unable to set unstructured %s %s", infraCluster.GetKind(), strings.Join(s.portField(), "."))
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(s.StatusFields)) {
+		if err := unstructured.SetNestedField(infraCluster.Object, s.StatusFields[field], strings.Split(field, ".")...); err != nil {
+			return errors.Wrapf(err, "unable to set unstructured %s %s", infraCluster.GetKind(), field)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+func TestParseConfigMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected Registry
+		err      string
+	}{
+		{
+			name:     "empty",
+			expected: Registry{},
+		},
+		{
+			name: "YAML and JSON strategies",
+			data: map[string]string{
+				"FooCluster": "action: Check\nhostField: spec.endpoint.host\nportField: spec.endpoint.port\n",
+				"BarCluster": `{"action": "Patch", "statusFields": {"status.ready": true}}`,
+			},
+			expected: Registry{
+				"FooCluster": {Action: ActionCheck, HostField: "spec.endpoint.host", PortField: "spec.endpoint.port"},
+				"BarCluster": {Action: ActionPatch, StatusFields: map[string]interface{}{"status.ready": true}},
+			},
+		},
+		{
+			name: "unknown action",
+			data: map[string]string{"FooCluster": "action: Replace"},
+			err:  `invalid FooCluster patch strategy: unknown action "Replace"`,
+		},
+		{
+			name: "status field outside of the status",
+			data: map[string]string{"FooCluster": `{"statusFields": {"spec.ready": true}}`},
+			err:  `invalid FooCluster patch strategy: the status field "spec.ready" must be prefixed with status`,
+		},
+		{
+			name: "malformed strategy",
+			data: map[string]string{"FooCluster": "action: [Patch"},
+			err:  "cannot decode the FooCluster patch strategy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			registry, err := ParseConfigMap(corev1.ConfigMap{Data: tt.data})
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(registry).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestStrategyFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    Strategy
+		found       bool
+		err         string
+	}{
+		{
+			name:        "no annotations",
+			annotations: map[string]string{"foo": "bar"},
+		},
+		{
+			name: "action and endpoint fields",
+			annotations: map[string]string{
+				v1alpha2.InfrastructurePatchActionAnnotation: string(ActionCheckOrPatch),
+				v1alpha2.InfrastructureHostFieldAnnotation:   "spec.endpoint.host",
+				v1alpha2.InfrastructurePortFieldAnnotation:   "spec.endpoint.port",
+			},
+			expected: Strategy{Action: ActionCheckOrPatch, HostField: "spec.endpoint.host", PortField: "spec.endpoint.port"},
+			found:    true,
+		},
+		{
+			name:        "ready field",
+			annotations: map[string]string{v1alpha2.InfrastructureReadyFieldAnnotation: "status.ready"},
+			expected:    Strategy{StatusFields: map[string]interface{}{"status.ready": true}},
+			found:       true,
+		},
+		{
+			name:        "unknown action",
+			annotations: map[string]string{v1alpha2.InfrastructurePatchActionAnnotation: "Replace"},
+			err:         `unknown action "Replace"`,
+		},
+		{
+			name:        "ready field outside of the status",
+			annotations: map[string]string{v1alpha2.InfrastructureReadyFieldAnnotation: "spec.ready"},
+			err:         `the status field "spec.ready" must be prefixed with status`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			strategy, found, err := StrategyFromAnnotations(tt.annotations)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+				g.Expect(found).To(BeFalse())
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(found).To(Equal(tt.found))
+			g.Expect(strategy).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestForContract(t *testing.T) {
+	tests := []struct {
+		name            string
+		strategy        Strategy
+		contractVersion string
+		expected        Strategy
+	}{
+		{
+			name:            "v1beta1 contract",
+			strategy:        Strategy{Action: ActionPatch, StatusFields: map[string]interface{}{"status.ready": true}},
+			contractVersion: ContractV1Beta1,
+			expected:        Strategy{Action: ActionPatch, StatusFields: map[string]interface{}{"status.ready": true}},
+		},
+		{
+			name:            "v1beta2 contract",
+			strategy:        Strategy{Action: ActionPatch, StatusFields: map[string]interface{}{"status.ready": true, "status.phase": "Ready"}},
+			contractVersion: ContractV1Beta2,
+			expected:        Strategy{Action: ActionPatch, StatusFields: map[string]interface{}{"status.initialization.provisioned": true, "status.phase": "Ready"}},
+		},
+		{
+			name:            "v1beta2 contract without status fields",
+			strategy:        Strategy{Action: ActionCheck},
+			contractVersion: ContractV1Beta2,
+			expected:        Strategy{Action: ActionCheck},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			original := tt.strategy.StatusFields
+
+			g.Expect(tt.strategy.ForContract(tt.contractVersion)).To(Equal(tt.expected))
+			g.Expect(tt.strategy.StatusFields).To(Equal(original), "the declared strategy must not be mutated")
+		})
+	}
+}
+
+func TestForContractDefaultRegistry(t *testing.T) {
+	g := NewWithT(t)
+
+	strategy := DefaultRegistry()["KubevirtCluster"].ForContract(ContractV1Beta2)
+
+	g.Expect(strategy.StatusFields).To(Equal(map[string]interface{}{"status.initialization.provisioned": true}))
+	g.Expect(DefaultRegistry()["KubevirtCluster"].StatusFields).To(Equal(map[string]interface{}{"status.ready": true}))
+}
+
+func TestResolve(t *testing.T) {
+	v1beta2CRD := func(annotations map[string]string) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{
+			Name:        "fooclusters.infrastructure.cluster.x-k8s.io",
+			Labels:      map[string]string{"cluster.x-k8s.io/v1beta2": "v1alpha1"},
+			Annotations: annotations,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		kind     string
+		declared Registry
+		crd      *metav1.ObjectMeta
+		dynamic  bool
+		expected Strategy
+		found    bool
+		err      string
+	}{
+		{
+			name: "unknown kind",
+			kind: "FooCluster",
+			crd:  &metav1.ObjectMeta{},
+		},
+		{
+			name:     "built-in",
+			kind:     "KubevirtCluster",
+			crd:      v1beta2CRD(nil),
+			expected: Strategy{Action: ActionPatch, StatusFields: map[string]interface{}{"status.initialization.provisioned": true}},
+			found:    true,
+		},
+		{
+			name:     "built-in over dynamic",
+			kind:     "OpenStackCluster",
+			crd:      &metav1.ObjectMeta{},
+			dynamic:  true,
+			expected: Strategy{Action: ActionPatch, HostField: "spec.apiServerFixedIP", PortField: "spec.apiServerPort"},
+			found:    true,
+		},
+		{
+			name:     "built-in status fields over dynamic",
+			kind:     "KubevirtCluster",
+			crd:      v1beta2CRD(nil),
+			dynamic:  true,
+			expected: Strategy{Action: ActionPatch, StatusFields: map[string]interface{}{"status.initialization.provisioned": true}},
+			found:    true,
+		},
+		{
+			name:     "dynamic",
+			kind:     "FooCluster",
+			crd:      &metav1.ObjectMeta{},
+			dynamic:  true,
+			expected: Strategy{Action: ActionPatch},
+			found:    true,
+		},
+		{
+			name:     "annotations over dynamic",
+			kind:     "FooCluster",
+			crd:      v1beta2CRD(map[string]string{v1alpha2.InfrastructureReadyFieldAnnotation: "status.ready"}),
+			dynamic:  true,
+			expected: Strategy{StatusFields: map[string]interface{}{"status.initialization.provisioned": true}},
+			found:    true,
+		},
+		{
+			name:     "declared over annotations",
+			kind:     "FooCluster",
+			declared: Registry{"FooCluster": {Action: ActionCheck}},
+			crd:      v1beta2CRD(map[string]string{v1alpha2.InfrastructurePatchActionAnnotation: string(ActionPatch)}),
+			expected: Strategy{Action: ActionCheck},
+			found:    true,
+		},
+		{
+			name: "invalid annotations",
+			kind: "FooCluster",
+			crd:  v1beta2CRD(map[string]string{v1alpha2.InfrastructurePatchActionAnnotation: "Replace"}),
+			err:  "invalid patch strategy declared on the fooclusters.infrastructure.cluster.x-k8s.io CustomResourceDefinition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			strategy, found, err := Resolve(tt.kind, tt.declared, tt.crd, tt.dynamic)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(found).To(Equal(tt.found))
+			g.Expect(strategy).To(Equal(tt.expected))
+		})
+	}
+}
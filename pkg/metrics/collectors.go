@@ -0,0 +1,82 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+)
+
+const collectTimeout = 5 * time.Second
+
+// conditionsCollector reports the number of KamajiControlPlane per condition type and status,
+// computed upon each scrape from the cached objects.
+type conditionsCollector struct {
+	reader client.Reader
+	desc   *prometheus.Desc
+}
+
+func (c *conditionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *conditionsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancelFn()
+
+	var kcpList v1alpha2.KamajiControlPlaneList
+
+	if err := c.reader.List(ctx, &kcpList); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+
+		return
+	}
+
+	counts := map[[2]string]int{}
+
+	for _, kcp := range kcpList.Items {
+		for _, condition := range kcp.Status.Conditions {
+			counts[[2]string{condition.Type, string(condition.Status)}]++
+		}
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), key[0], key[1])
+	}
+}
+
+// RegisterCollectors registers the collectors computed upon each scrape:
+// the KamajiControlPlane count per condition status, and the number of live remote managers.
+func RegisterCollectors(reader client.Reader, remoteManagersFn func() int) error {
+	conditions := &conditionsCollector{
+		reader: reader,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "conditions"),
+			"Number of KamajiControlPlane per condition type and status.",
+			[]string{"type", "status"}, nil,
+		),
+	}
+
+	remoteManagers := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "external_cluster_reference_managers",
+		Help:      "Number of live managers for the remote clusters referenced by the ExternalClusterReference.",
+	}, func() float64 {
+		return float64(remoteManagersFn())
+	})
+
+	for _, collector := range []prometheus.Collector{conditions, remoteManagers} {
+		if err := ctrlmetrics.Registry.Register(collector); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "kamaji_control_plane"
+
+var (
+	// ReconcilePhaseDuration is the time spent in each reconciliation phase, named after the tracked condition type.
+	ReconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_phase_duration_seconds",
+		Help:      "Time spent in each KamajiControlPlane reconciliation phase.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"phase", "result"})
+	// TimeToAvailable is the time elapsed from the KamajiControlPlane creation to its first Available condition.
+	TimeToAvailable = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "time_to_available_seconds",
+		Help:      "Time elapsed from the KamajiControlPlane creation to the first time it has been reported as Available.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 10),
+	})
+	// UpgradeDuration is the time elapsed from the detection of a Kubernetes version upgrade to its completion or failure.
+	UpgradeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upgrade_duration_seconds",
+		Help:      "Time elapsed from the detection of a Kubernetes version upgrade to its completion or failure.",
+		Buckets:   prometheus.ExponentialBuckets(30, 2, 10),
+	}, []string{"result"})
+	// InfrastructureClusterPatchFailures counts the failed patches of the Infrastructure Cluster, per Kind.
+	InfrastructureClusterPatchFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "infrastructure_cluster_patch_failures_total",
+		Help:      "Number of failed patches of the Infrastructure Cluster with the Control Plane endpoint, per Kind.",
+	}, []string{"kind"})
+	// ControlPlaneHealthProbeDuration is the time taken by the Tenant Control Plane API server to answer a health probe.
+	ControlPlaneHealthProbeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "health_probe_duration_seconds",
+		Help:      "Time taken by the Tenant Control Plane API server to answer a health probe, per endpoint.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"endpoint", "result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		ReconcilePhaseDuration,
+		TimeToAvailable,
+		UpgradeDuration,
+		InfrastructureClusterPatchFailures,
+		ControlPlaneHealthProbeDuration,
+	)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// tracedClient decorates a client.Client, creating a span for each request.
+type tracedClient struct {
+	client.Client
+
+	prefix string
+}
+
+// NewClient returns a client.Client creating a span for each request, named after the given prefix.
+func NewClient(c client.Client, prefix string) client.Client { //nolint:ireturn
+	return &tracedClient{Client: c, prefix: prefix}
+}
+
+func (t *tracedClient) start(ctx context.Context, verb string, obj runtime.Object, key client.ObjectKey) (context.Context, func(error)) {
+	attributes := []attribute.KeyValue{attribute.String("k8s.verb", verb)}
+
+	if gvk, err := apiutil.GVKForObject(obj, t.Scheme()); err == nil {
+		attributes = append(attributes, attribute.String("k8s.kind", gvk.Kind))
+	}
+
+	if key.Namespace != "" {
+		attributes = append(attributes, attribute.String("k8s.namespace", key.Namespace))
+	}
+
+	if key.Name != "" {
+		attributes = append(attributes, attribute.String("k8s.name", key.Name))
+	}
+
+	ctx, span := Start(ctx, t.prefix+"."+verb, attributes...)
+
+	return ctx, func(err error) {
+		End(span, err)
+	}
+}
+
+func (t *tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, end := t.start(ctx, "Get", obj, key)
+
+	err := t.Client.Get(ctx, key, obj, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ctx, end := t.start(ctx, "List", list, client.ObjectKey{})
+
+	err := t.Client.List(ctx, list, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, end := t.start(ctx, "Create", obj, client.ObjectKeyFromObject(obj))
+
+	err := t.Client.Create(ctx, obj, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, end := t.start(ctx, "Update", obj, client.ObjectKeyFromObject(obj))
+
+	err := t.Client.Update(ctx, obj, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, end := t.start(ctx, "Patch", obj, client.ObjectKeyFromObject(obj))
+
+	err := t.Client.Patch(ctx, obj, patch, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, end := t.start(ctx, "Delete", obj, client.ObjectKeyFromObject(obj))
+
+	err := t.Client.Delete(ctx, obj, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	ctx, end := t.start(ctx, "DeleteAllOf", obj, client.ObjectKey{})
+
+	err := t.Client.DeleteAllOf(ctx, obj, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedClient) Status() client.SubResourceWriter { //nolint:ireturn
+	return &tracedSubResourceWriter{SubResourceWriter: t.Client.Status(), client: t}
+}
+
+// tracedSubResourceWriter decorates the status client.SubResourceWriter, creating a span for each request.
+type tracedSubResourceWriter struct {
+	client.SubResourceWriter
+
+	client *tracedClient
+}
+
+func (t *tracedSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, end := t.client.start(ctx, "UpdateStatus", obj, client.ObjectKeyFromObject(obj))
+
+	err := t.SubResourceWriter.Update(ctx, obj, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (t *tracedSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, end := t.client.start(ctx, "PatchStatus", obj, client.ObjectKeyFromObject(obj))
+
+	err := t.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	end(err)
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+
+	serviceName = "cluster-api-control-plane-provider-kamaji"
+	tracerName  = "github.com/clastix/cluster-api-control-plane-provider-kamaji"
+)
+
+var ErrUnsupportedExporter = errors.New("unsupported tracing exporter")
+
+// Options configures the tracing pipeline.
+type Options struct {
+	// Exporter is the spans destination, one of none, otlp, or stdout.
+	Exporter string
+	// Endpoint is the OTLP gRPC collector address, in the form of host:port.
+	Endpoint string
+	// Insecure disables the TLS transport towards the OTLP collector.
+	Insecure bool
+	// SamplingRatio is the fraction of the root spans to be sampled, between 0 and 1.
+	SamplingRatio float64
+}
+
+// Setup configures the global TracerProvider according to the given options,
+// returning the function to flush and stop it: with the none exporter, the no-op provider is kept.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch opts.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err = otlptracegrpc.New(ctx, clientOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, errors.Wrap(ErrUnsupportedExporter, opts.Exporter)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create tracing exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start creates a span as child of the one stored in the given context, if any.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) { //nolint:ireturn
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartRoot creates a new root span, ignoring any parent stored in the given context.
+func StartRoot(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) { //nolint:ireturn
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithNewRoot(), trace.WithAttributes(attributes...))
+}
+
+// End records the given error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/yaml"
+
+	controlplanev1alpha2 "github.com/clastix/cluster-api-control-plane-provider-kamaji/api/v1alpha2"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/controllers"
+	"github.com/clastix/cluster-api-control-plane-provider-kamaji/pkg/externalclusterreference"
+)
+
+// planCommand is the subcommand rendering offline the TenantControlPlane a KamajiControlPlane would produce.
+const planCommand = "plan"
+
+// decodeManifest decodes the single object manifest stored in the given file.
+func decodeManifest(path string, into runtime.Object) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read %s", path)
+	}
+
+	if _, _, err = serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, into); err != nil {
+		return errors.Wrapf(err, "cannot decode %s", path)
+	}
+
+	return nil
+}
+
+// renderTenantControlPlane returns the YAML of the TenantControlPlane, omitting the fields not relevant to the plan.
+func renderTenantControlPlane(tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	tcp = tcp.DeepCopy()
+	tcp.SetGroupVersionKind(kamajiv1alpha1.GroupVersion.WithKind("TenantControlPlane"))
+	tcp.ManagedFields = nil
+	delete(tcp.Annotations, controlplanev1alpha2.LastAppliedTenantControlPlaneAnnotation)
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(tcp)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot convert the TenantControlPlane")
+	}
+	// The status is not omitted when empty, since a struct.
+	delete(obj, "status")
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot encode the TenantControlPlane")
+	}
+
+	return string(data), nil
+}
+
+// runPlan renders the TenantControlPlane produced by the given KamajiControlPlane and Cluster manifests,
+// with no access to a cluster: when the live TenantControlPlane manifest is provided, the translation is applied
+// onto it as the controller does, according to the drift policy, and the differences can be printed rather than
+// the resulting manifest.
+// The defaults applied by the Kamaji webhooks are not rendered.
+func runPlan(args []string, out io.Writer) error {
+	var kcpPath, clusterPath, livePath string
+
+	var diff bool
+
+	flagSet := pflag.NewFlagSet(planCommand, pflag.ContinueOnError)
+	flagSet.StringVar(&kcpPath, "kamajicontrolplane", "", "The KamajiControlPlane manifest file.")
+	flagSet.StringVar(&clusterPath, "cluster", "", "The Cluster manifest file referencing the KamajiControlPlane.")
+	flagSet.StringVar(&livePath, "tenantcontrolplane", "", "The live TenantControlPlane manifest file, such as retrieved with kubectl get -o yaml.")
+	flagSet.BoolVar(&diff, "diff", false, "Print the unified diff between the live TenantControlPlane and the rendered one, rather than the rendered manifest.")
+
+	if err := flagSet.Parse(args); err != nil {
+		return errors.Wrap(err, "cannot parse arguments")
+	}
+
+	if kcpPath == "" || clusterPath == "" {
+		return errors.New("both the --kamajicontrolplane and --cluster manifests are required")
+	}
+
+	if diff && livePath == "" {
+		return errors.New("the --diff output requires the live --tenantcontrolplane manifest")
+	}
+
+	var kcp controlplanev1alpha2.KamajiControlPlane
+	if err := decodeManifest(kcpPath, &kcp); err != nil {
+		return err
+	}
+
+	var cluster capiv1beta2.Cluster
+	if err := decodeManifest(clusterPath, &cluster); err != nil {
+		return err
+	}
+
+	live := &kamajiv1alpha1.TenantControlPlane{}
+	live.Name, live.Namespace = kcp.Name, kcp.Namespace
+
+	if kcp.Spec.Deployment.ExternalClusterReference != nil {
+		live.Name, live.Namespace = externalclusterreference.GenerateRemoteTenantControlPlaneNames(kcp)
+	}
+
+	if livePath != "" {
+		if err := decodeManifest(livePath, live); err != nil {
+			return err
+		}
+	}
+
+	tcp := live.DeepCopy()
+	if _, err := controllers.ApplyTenantControlPlane(tcp, cluster, kcp); err != nil {
+		return errors.Wrap(err, "cannot translate the KamajiControlPlane")
+	}
+
+	rendered, err := renderTenantControlPlane(tcp)
+	if err != nil {
+		return err
+	}
+
+	if !diff {
+		_, err = fmt.Fprint(out, rendered)
+
+		return errors.Wrap(err, "cannot write the rendered TenantControlPlane")
+	}
+
+	current, err := renderTenantControlPlane(live)
+	if err != nil {
+		return err
+	}
+
+	unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(rendered),
+		FromFile: livePath,
+		ToFile:   "rendered",
+		Context:  3, //nolint:mnd
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot compute the TenantControlPlane diff")
+	}
+
+	_, err = fmt.Fprint(out, unified)
+
+	return errors.Wrap(err, "cannot write the TenantControlPlane diff")
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive
+)
+
+var updateGolden = flag.Bool("update", false, "Update the golden files of the plan tests.")
+
+func TestRunPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		golden string
+	}{
+		{
+			name:   "rendered",
+			args:   []string{"--kamajicontrolplane", "testdata/plan/kamajicontrolplane.yaml", "--cluster", "testdata/plan/cluster.yaml"},
+			golden: "rendered.golden",
+		},
+		{
+			name:   "applied onto the live TenantControlPlane",
+			args:   []string{"--kamajicontrolplane", "testdata/plan/kamajicontrolplane.yaml", "--cluster", "testdata/plan/cluster.yaml", "--tenantcontrolplane", "testdata/plan/tenantcontrolplane.yaml"},
+			golden: "applied.golden",
+		},
+		{
+			name:   "diff",
+			args:   []string{"--kamajicontrolplane", "testdata/plan/kamajicontrolplane.yaml", "--cluster", "testdata/plan/cluster.yaml", "--tenantcontrolplane", "testdata/plan/tenantcontrolplane.yaml", "--diff"},
+			golden: "diff.golden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var out bytes.Buffer
+			g.Expect(runPlan(tt.args, &out)).To(Succeed())
+
+			golden := filepath.Join("testdata", "plan", tt.golden)
+			if *updateGolden {
+				g.Expect(os.WriteFile(golden, out.Bytes(), 0o600)).To(Succeed())
+			}
+
+			expected, err := os.ReadFile(golden)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(out.String()).To(Equal(string(expected)))
+		})
+	}
+}
+
+func TestRunPlanArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "missing Cluster",
+			args: []string{"--kamajicontrolplane", "testdata/plan/kamajicontrolplane.yaml"},
+			err:  "both the --kamajicontrolplane and --cluster manifests are required",
+		},
+		{
+			name: "diff without the live TenantControlPlane",
+			args: []string{"--kamajicontrolplane", "testdata/plan/kamajicontrolplane.yaml", "--cluster", "testdata/plan/cluster.yaml", "--diff"},
+			err:  "the --diff output requires the live --tenantcontrolplane manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(runPlan(tt.args, &bytes.Buffer{})).To(MatchError(tt.err))
+		})
+	}
+}